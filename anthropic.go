@@ -0,0 +1,128 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import "encoding/json"
+
+// AnthropicBlock is the repaired state of a content block of an Anthropic streaming response
+type AnthropicBlock struct {
+	// Index is the index of the content block
+	Index int
+	// Type is the type of the content block, such as tool_use
+	Type string
+	// ID and Name are the id and the name of the tool of a tool_use block
+	ID, Name string
+	// Snapshot is the repaired snapshot of the JSON received for the block
+	Snapshot Snapshot
+	// Done reports whether the block was stopped
+	Done bool
+}
+
+// AnthropicBlocks accumulates the input_json_delta fragments of the content blocks of an Anthropic
+// streaming response, keeping one repaired document per block
+type AnthropicBlocks struct {
+	parser *JSONParser
+	blocks map[int]*anthropicBlock
+}
+
+type anthropicBlock struct {
+	AnthropicBlock
+	dec *StreamDecoder
+	fed bool
+}
+
+// anthropicEvent holds the members of the streaming events used by AnthropicBlocks
+type anthropicEvent struct {
+	Type         string `json:"type"`
+	Index        int    `json:"index"`
+	ContentBlock struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"content_block"`
+	Delta struct {
+		Type        string `json:"type"`
+		PartialJSON string `json:"partial_json"`
+	} `json:"delta"`
+}
+
+// NewAnthropicBlocks creates an AnthropicBlocks repairing the blocks with p
+func NewAnthropicBlocks(p *JSONParser) *AnthropicBlocks {
+	return &AnthropicBlocks{parser: p, blocks: make(map[int]*anthropicBlock)}
+}
+
+// Handle processes a streaming event given as its raw JSON, that is the data of the server-sent
+// event or the RawJSON() of an event of the Anthropic Go SDK. It returns the updated block and true
+// for the content_block_start, content_block_delta and content_block_stop events, and false for the
+// other events. A block without fragments, such as a tool_use block of a tool taking no input, is
+// the empty object when stopped. Errors are the errors of the event JSON and of the repair, the
+// snapshot being updated alongside the problems skipped over in lenient mode
+func (b *AnthropicBlocks) Handle(event []byte) (AnthropicBlock, bool, error) {
+	var ev anthropicEvent
+	if err := json.Unmarshal(event, &ev); err != nil {
+		return AnthropicBlock{}, false, err
+	}
+
+	switch ev.Type {
+	case "content_block_start":
+		block := &anthropicBlock{
+			AnthropicBlock: AnthropicBlock{Index: ev.Index, Type: ev.ContentBlock.Type, ID: ev.ContentBlock.ID, Name: ev.ContentBlock.Name},
+			dec:            NewStreamDecoder(b.parser),
+		}
+		b.blocks[ev.Index] = block
+		return block.AnthropicBlock, true, nil
+	case "content_block_delta":
+		block := b.block(ev.Index)
+		if ev.Delta.Type != "input_json_delta" || ev.Delta.PartialJSON == "" {
+			return block.AnthropicBlock, true, nil
+		}
+
+		block.fed = true
+		fed := block.dec.seq + 1
+		snapshot, err := block.dec.Feed([]byte(ev.Delta.PartialJSON))
+		if snapshot.Seq == fed {
+			block.Snapshot = snapshot
+		}
+		return block.AnthropicBlock, true, err
+	case "content_block_stop":
+		block := b.block(ev.Index)
+		block.Done = true
+		if !block.fed && block.Type == "tool_use" {
+			fed := block.dec.seq + 1
+			snapshot, err := block.dec.Feed([]byte("{}"))
+			if snapshot.Seq != fed {
+				return block.AnthropicBlock, true, err
+			}
+			block.Snapshot = snapshot
+		}
+		block.dec.Close()
+		return block.AnthropicBlock, true, nil
+	}
+
+	return AnthropicBlock{}, false, nil
+}
+
+// Block returns the block at index, and false if no event of the block was handled
+func (b *AnthropicBlocks) Block(index int) (AnthropicBlock, bool) {
+	block, ok := b.blocks[index]
+	if !ok {
+		return AnthropicBlock{}, false
+	}
+
+	return block.AnthropicBlock, true
+}
+
+// block returns the block at index, creating it for streams missing its content_block_start event
+func (b *AnthropicBlocks) block(index int) *anthropicBlock {
+	block, ok := b.blocks[index]
+	if !ok {
+		block = &anthropicBlock{AnthropicBlock: AnthropicBlock{Index: index}, dec: NewStreamDecoder(b.parser)}
+		b.blocks[index] = block
+	}
+
+	return block
+}
@@ -0,0 +1,72 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAnthropicBlocks(t *testing.T) {
+	events := []string{
+		`{"type":"message_start","message":{"id":"msg_1"}}`,
+		`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Checking"}}`,
+		`{"type":"content_block_stop","index":0}`,
+		`{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"get_weather","input":{}}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":""}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"location\": \"San Fra"}}`,
+		`{"type":"content_block_start","index":2,"content_block":{"type":"tool_use","id":"toolu_2","name":"get_time","input":{}}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"ncisco\", \"unit\": \"c"}}`,
+		`{"type":"content_block_stop","index":2}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"elsius\"}"}}`,
+		`{"type":"content_block_stop","index":1}`,
+		`{"type":"message_stop"}`,
+	}
+
+	blocks := NewAnthropicBlocks(NewJSONParser(false))
+	var inputs []string
+	for _, event := range events {
+		block, ok, err := blocks.Handle([]byte(event))
+		require.Nil(t, err)
+		if ok && block.Index == 1 && block.Snapshot.JSON != "" {
+			inputs = append(inputs, block.Snapshot.JSON)
+		}
+	}
+
+	require.Equal(t, []string{
+		`{"location":"San Fra"}`,
+		`{"location":"San Francisco","unit":"c"}`,
+		`{"location":"San Francisco","unit":"celsius"}`,
+		`{"location":"San Francisco","unit":"celsius"}`,
+	}, inputs)
+
+	block, ok := blocks.Block(1)
+	require.True(t, ok)
+	require.True(t, block.Done && block.Snapshot.Complete)
+	require.Equal(t, "get_weather", block.Name)
+
+	block, ok = blocks.Block(2)
+	require.True(t, ok)
+	require.Equal(t, AnthropicBlock{Index: 2, Type: "tool_use", ID: "toolu_2", Name: "get_time", Snapshot: block.Snapshot, Done: true}, block)
+	require.Equal(t, "{}", block.Snapshot.JSON)
+
+	block, _ = blocks.Block(0)
+	require.Equal(t, "", block.Snapshot.JSON)
+
+	_, _, err := blocks.Handle([]byte(`{"type":`))
+	require.NotNil(t, err)
+
+	blocks = NewAnthropicBlocks(NewJSONParser(false, WithLenient()))
+	inputs = inputs[:0]
+	for _, delta := range []string{`{\"a\":1,\"x\":bad,`, `\"b\":2}`} {
+		block, _, err = blocks.Handle([]byte(`{"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":"` + delta + `"}}`))
+		require.ErrorIs(t, err, ErrUnexpectedToken)
+		inputs = append(inputs, block.Snapshot.JSON)
+	}
+	require.Equal(t, []string{`{"a":1}`, `{"a":1,"b":2}`}, inputs)
+}
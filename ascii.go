@@ -0,0 +1,35 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import "unicode/utf8"
+
+// isASCII reports whether s holds only ASCII characters
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
+
+// trimASCIISpace returns the ASCII text s without its leading whitespace, as unicode.IsSpace defines it
+func trimASCIISpace(s string) string {
+	i := 0
+	for i < len(s) {
+		switch s[i] {
+		case ' ', '\t', '\n', '\v', '\f', '\r':
+			i++
+			continue
+		}
+		break
+	}
+
+	return s[i:]
+}
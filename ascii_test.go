@@ -0,0 +1,64 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestASCIIFastPath(t *testing.T) {
+	require.True(t, isASCII(`{"a":"b"}`))
+	require.False(t, isASCII(`{"a":"é"}`))
+	require.Equal(t, `{"a":1}`, trimASCIISpace(" \t\r\n\v\f{\"a\":1}"))
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: `{"scene":[{"name":"night","tags":["a\"]",`, expected: `{"scene":[{"name":"night","tags":["a\"]"]}]}`},
+		{input: `{"scene":[{"name":"夜晚","tags":["a\"]",`, expected: `{"scene":[{"name":"夜晚","tags":["a\"]"]}]}`},
+	}
+
+	parser := NewJSONParser(true)
+	for _, test := range tests {
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+
+		data, err = parser.FastEnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+	}
+
+	data, err := parser.EnsureJSON("{\"a\":[ 1,\t2 ],\n\"b\": {\"c\":\"d")
+	require.Nil(t, err)
+	require.Equal(t, `{"a":[1,2],"b":{"c":null}}`, data)
+
+	_, err = parser.FastEnsureJSON(`{"a":[1}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
+
+func BenchmarkFastEnsureJsonASCII(b *testing.B) {
+	input := `{"items":[` + strings.Repeat(`{"title":"An English sentence long enough","done":true},`, 1000) + `{"title":"cut`
+	parser := NewJSONParser(true)
+	for i := 0; i < b.N; i++ {
+		_, err := parser.FastEnsureJSON(input)
+		require.Nil(b, err)
+	}
+}
+
+func BenchmarkFastEnsureJsonMultiByte(b *testing.B) {
+	input := `{"items":[` + strings.Repeat(`{"title":"一个足够长的中文句子","done":true},`, 1000) + `{"title":"截`
+	parser := NewJSONParser(true)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, err := parser.FastEnsureJSON(input)
+		require.Nil(b, err)
+	}
+}
@@ -0,0 +1,133 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// Node is a value of a document parsed by ParseAST
+type Node struct {
+	// Kind is the kind of the value, KindUnknown for a member whose value was not received yet
+	Kind Kind
+	// Key is the member name of the value in its object, "" for elements and the document
+	Key string
+	// Path is the path of the value in the dotted syntax, empty for the document
+	Path string
+	// Value is the value of strings, numbers and booleans, the received part of a cut string and
+	// nil for the other values and for a cut number that is not a number yet, as in 1e
+	Value any
+	// Start and End are the byte offsets of the span of the value in the input, End being the
+	// length of the input for the values cut by its end
+	Start, End int
+	// Complete reports whether the whole value was received
+	Complete bool
+	// Children holds the members of objects and the elements of arrays, in input order
+	Children []*Node
+	// Parent is the object or array holding the value, nil for the document
+	Parent *Node
+
+	path []any
+}
+
+// Raw returns the text of the value in the input s it was parsed from
+func (n *Node) Raw(s string) string {
+	return s[n.Start:n.End]
+}
+
+// ParseAST parses the JSON document s, possibly cut by the end of the input, into a tree of nodes
+// recording the span of every value and whether it is complete, for editors and debuggers to
+// show what was received. Values are not repaired: a cut literal such as tr leaves its member
+// with a KindUnknown node, and the tree is nil when nothing of the document was received. When
+// s is not JSON, the tree parsed up to the error is returned alongside it, nil if the error is
+// at the start of the document
+func ParseAST(s string) (*Node, error) {
+	var root, cur *Node
+	tz := NewTokenizer(s)
+	key, keyed := "", false
+	for {
+		tok, err := tz.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return root, err
+		}
+
+		switch tok.Kind {
+		case TokenKey, TokenKeyPartial:
+			key, keyed = tok.Value.(string), tok.Kind == TokenKey
+			if !keyed {
+				// the value of a cut member name is not known yet
+				cur.addChild(&Node{Key: key, Start: len(s), End: len(s)}, key)
+			}
+			continue
+		case TokenObjectEnd, TokenArrayEnd:
+			cur.End = tok.Offset + 1
+			cur.Complete = true
+			cur = cur.Parent
+			continue
+		}
+
+		node := &Node{Start: tok.Offset, End: tz.pos, Complete: true}
+		switch tok.Kind {
+		case TokenObjectStart:
+			node.Kind = KindObject
+			node.Complete = false
+		case TokenArrayStart:
+			node.Kind = KindArray
+			node.Complete = false
+		case TokenString, TokenStringPartial:
+			node.Kind, node.Value = KindString, tok.Value
+			node.Complete = tok.Kind == TokenString
+		case TokenNumber, TokenNumberPartial:
+			node.Kind = KindNumber
+			if lit := string(tok.Value.(json.Number)); numberRe.MatchString(lit) {
+				node.Value, _ = strconv.ParseFloat(lit, 64)
+			}
+			node.Complete = tok.Kind == TokenNumber
+		case TokenBool:
+			node.Kind, node.Value = KindBool, tok.Value
+		case TokenNull:
+			node.Kind = KindNull
+		}
+
+		if cur == nil {
+			root = node
+		} else if keyed {
+			node.Key = key
+			cur.addChild(node, key)
+		} else {
+			cur.addChild(node, len(cur.Children))
+		}
+		keyed = false
+
+		if node.Kind == KindObject || node.Kind == KindArray {
+			cur = node
+		}
+	}
+
+	if keyed {
+		// the value of the last member was not received yet
+		cur.addChild(&Node{Key: key, Start: len(s), End: len(s)}, key)
+	}
+	for n := cur; n != nil; n = n.Parent {
+		n.End = len(s)
+	}
+
+	return root, nil
+}
+
+// addChild appends the value child found at the segment seg of n
+func (n *Node) addChild(child *Node, seg any) {
+	child.Parent = n
+	child.path = append(n.path[:len(n.path):len(n.path)], seg)
+	child.Path = formatPath(child.path)
+	n.Children = append(n.Children, child)
+}
@@ -0,0 +1,88 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseAST(t *testing.T) {
+	const doc = `{"a": [1, "x"], "b": {"c": "He`
+	root, err := ParseAST(doc)
+	require.Nil(t, err)
+
+	require.Equal(t, KindObject, root.Kind)
+	require.False(t, root.Complete)
+	require.Equal(t, 0, root.Start)
+	require.Equal(t, len(doc), root.End)
+	require.Len(t, root.Children, 2)
+
+	a := root.Children[0]
+	require.Equal(t, "a", a.Key)
+	require.Equal(t, "a", a.Path)
+	require.Equal(t, KindArray, a.Kind)
+	require.True(t, a.Complete)
+	require.Equal(t, `[1, "x"]`, a.Raw(doc))
+	require.Same(t, root, a.Parent)
+
+	x := a.Children[1]
+	require.Equal(t, "a.1", x.Path)
+	require.Equal(t, "x", x.Value)
+	require.True(t, x.Complete)
+	require.Equal(t, float64(1), a.Children[0].Value)
+
+	c := root.Children[1].Children[0]
+	require.Equal(t, "b.c", c.Path)
+	require.Equal(t, KindString, c.Kind)
+	require.Equal(t, "He", c.Value)
+	require.False(t, c.Complete)
+	require.Equal(t, `"He`, c.Raw(doc))
+	require.False(t, root.Children[1].Complete)
+}
+
+func TestParseASTMissingValues(t *testing.T) {
+	tests := []struct {
+		input string
+		kind  Kind
+		path  string
+		value any
+	}{
+		{input: `{"a": `, kind: KindUnknown, path: "a"},
+		{input: `{"a": tr`, kind: KindUnknown, path: "a"},
+		{input: `{"a.b`, kind: KindUnknown, path: `a\.b`},
+		{input: `[1, 2e`, kind: KindNumber, path: "1"},
+		{input: `[1, 25`, kind: KindNumber, path: "1", value: float64(25)},
+	}
+
+	for _, test := range tests {
+		root, err := ParseAST(test.input)
+		require.Nil(t, err, test.input)
+		last := root.Children[len(root.Children)-1]
+		require.Equal(t, test.kind, last.Kind, test.input)
+		require.Equal(t, test.path, last.Path, test.input)
+		require.Equal(t, test.value, last.Value, test.input)
+		require.False(t, last.Complete, test.input)
+		require.Equal(t, len(test.input), last.End, test.input)
+	}
+}
+
+func TestParseASTError(t *testing.T) {
+	root, err := ParseAST(`{"a": 1, "b" 2}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+	require.Len(t, root.Children, 1)
+
+	root, err = ParseAST(`x`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+	require.Nil(t, root)
+
+	root, err = ParseAST(`[true, null] `)
+	require.Nil(t, err)
+	require.True(t, root.Complete)
+	require.Equal(t, KindNull, root.Children[1].Kind)
+	require.Equal(t, 12, root.End)
+}
@@ -0,0 +1,74 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// BatchResult is the result of repairing one input of a batch
+type BatchResult struct {
+	// JSON is the repaired document, as returned by EnsureJSON
+	JSON string
+	// Err is the error returned by EnsureJSON, or the error of the context when the
+	// batch was canceled before the input was repaired
+	Err error
+}
+
+// BatchOption is a function that configures RepairBatch
+type BatchOption func(*batchConfig)
+
+type batchConfig struct {
+	workers int
+}
+
+// WithWorkers sets the number of inputs repaired concurrently, GOMAXPROCS by default
+func WithWorkers(n int) BatchOption {
+	return func(c *batchConfig) {
+		c.workers = n
+	}
+}
+
+// RepairBatch repairs inputs concurrently with a bounded number of workers, returning
+// the result of every input at its index. When ctx is canceled, the inputs not repaired
+// yet fail with the error of the context. The callbacks of the parser may be called
+// from several goroutines at once
+func (p *JSONParser) RepairBatch(ctx context.Context, inputs []string, opts ...BatchOption) []BatchResult {
+	cfg := batchConfig{workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cfg.workers = max(1, min(cfg.workers, len(inputs)))
+
+	results := make([]BatchResult, len(inputs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range cfg.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+
+				results[i].JSON, results[i].Err = p.EnsureJSON(inputs[i])
+			}
+		}()
+	}
+
+	for i := range inputs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
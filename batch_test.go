@@ -0,0 +1,39 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+)
+
+func TestRepairBatch(t *testing.T) {
+	inputs := make([]string, 100)
+	for i := range inputs {
+		inputs[i] = `{"id":` + strconv.Itoa(i) + `,"name":"x`
+	}
+	inputs[42] = "42"
+
+	results := NewJSONParser(false).RepairBatch(context.Background(), inputs, WithWorkers(4))
+	require.Len(t, results, len(inputs))
+	for i, result := range results {
+		if i == 42 {
+			require.ErrorIs(t, result.Err, ErrUnexpectedToken)
+			continue
+		}
+
+		require.Nil(t, result.Err)
+		require.Equal(t, `{"id":`+strconv.Itoa(i)+`,"name":"x"}`, result.JSON)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results = NewJSONParser(false).RepairBatch(ctx, inputs[:3])
+	require.Equal(t, []BatchResult{{Err: context.Canceled}, {Err: context.Canceled}, {Err: context.Canceled}}, results)
+}
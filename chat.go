@@ -0,0 +1,86 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"iter"
+)
+
+// ChunkStream is the minimal interface of a streamed model response, as implemented by the
+// ssestream.Stream of the OpenAI and Anthropic Go SDKs
+type ChunkStream[C any] interface {
+	Next() bool
+	Current() C
+	Err() error
+}
+
+// StreamJSON reads the chunks of stream, feeding the text that delta returns for every chunk to dec, and
+// yields the repaired snapshot of the answer decoded into T after every chunk that changed it,
+// alongside the problems skipped over in lenient mode or reported in StrictnessReport mode. A
+// chunk that can not be repaired yet is skipped, the error being yielded only if the stream ends on it
+func StreamJSON[T, C any](dec *StreamDecoder, stream ChunkStream[C], delta func(C) string) iter.Seq2[T, error] {
+	return streamJSON[T](dec, func(yield func(C, error) bool) {
+		for stream.Next() {
+			if !yield(stream.Current(), nil) {
+				return
+			}
+		}
+
+		if err := stream.Err(); err != nil {
+			var zero C
+			yield(zero, err)
+		}
+	}, delta)
+}
+
+// streamJSON is StreamJSON over a sequence of chunks ending with the error of the stream, if any
+func streamJSON[T, C any](dec *StreamDecoder, chunks iter.Seq2[C, error], delta func(C) string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		var feedErr error
+		last := ""
+		for chunk, err := range chunks {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			text := delta(chunk)
+			if text == "" {
+				continue
+			}
+
+			fed := dec.seq + 1
+			var snapshot Snapshot
+			snapshot, feedErr = dec.Feed([]byte(text))
+			if snapshot.Seq != fed {
+				continue
+			}
+			// the snapshot was repaired, feedErr holding the problems found along the way if any
+			problems := feedErr
+			feedErr = nil
+			if snapshot.JSON == last {
+				continue
+			}
+			last = snapshot.JSON
+
+			var v T
+			if err := json.Unmarshal([]byte(snapshot.JSON), &v); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(v, problems) {
+				return
+			}
+		}
+
+		if feedErr != nil {
+			yield(zero, feedErr)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// WithMapCoercion makes Unmarshal and FastUnmarshal turn the arrays of pairs found where the
+// target has a map, such as [{"key":"a","value":1},{"key":"b","value":2}] or ["a:1","b:2"],
+// into objects such as {"a":1,"b":2}. Arrays holding anything but pairs are left as is
+func WithMapCoercion() ParserOption {
+	return func(p *JSONParser) {
+		p.coerceMaps = true
+	}
+}
+
+// forTarget returns the parser to use to unmarshal into v
+func (p *JSONParser) forTarget(v any) *JSONParser {
+	if !p.coerceMaps {
+		return p
+	}
+
+	t := reflect.TypeOf(v)
+	cp := *p
+	cp.transforms = append(slices.Clip(p.transforms), func(doc *Document) error {
+		doc.Value = coerceMaps(doc.Value, t)
+		return nil
+	})
+
+	return &cp
+}
+
+// coerceMaps returns v with the arrays of pairs found where the type t has a map turned into objects
+func coerceMaps(v any, t reflect.Type) any {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return v
+	}
+
+	switch t.Kind() {
+	case reflect.Map:
+		if arr, ok := v.([]any); ok && t.Key().Kind() == reflect.String {
+			if obj, ok := pairsObject(arr); ok {
+				v = obj
+			}
+		}
+		if obj, ok := v.(map[string]any); ok {
+			for key, val := range obj {
+				obj[key] = coerceMaps(val, t.Elem())
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if arr, ok := v.([]any); ok {
+			for i, elem := range arr {
+				arr[i] = coerceMaps(elem, t.Elem())
+			}
+		}
+	case reflect.Struct:
+		if obj, ok := v.(map[string]any); ok {
+			for key, val := range obj {
+				if ft, ok := fieldType(t, key); ok {
+					obj[key] = coerceMaps(val, ft)
+				}
+			}
+		}
+	}
+
+	return v
+}
+
+// pairsObject returns the object holding the pairs of arr, and whether every element is a pair
+func pairsObject(arr []any) (map[string]any, bool) {
+	obj := make(map[string]any, len(arr))
+	for _, elem := range arr {
+		switch elem := elem.(type) {
+		case map[string]any:
+			key, ok := elem["key"].(string)
+			if !ok {
+				key, ok = elem["name"].(string)
+			}
+			val, hasValue := elem["value"]
+			if !ok || !hasValue || len(elem) != 2 {
+				return nil, false
+			}
+			obj[key] = val
+		case string:
+			key, val, ok := strings.Cut(elem, ":")
+			if !ok {
+				key, val, ok = strings.Cut(elem, "=")
+			}
+			if !ok {
+				return nil, false
+			}
+			obj[strings.TrimSpace(key)] = pairValue(strings.TrimSpace(val))
+		default:
+			return nil, false
+		}
+	}
+
+	return obj, true
+}
+
+// pairValue returns the value of the text val of a pair written as a string, a JSON literal if it is one
+func pairValue(val string) any {
+	var v any
+	if err := json.Unmarshal([]byte(val), &v); err == nil {
+		return v
+	}
+
+	return val
+}
+
+// fieldType returns the type of the field of the struct type t encoded as the member name,
+// matching names as encoding/json does
+func fieldType(t reflect.Type, name string) (reflect.Type, bool) {
+	var fold reflect.Type
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		fieldName, _, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && fieldName == "" && ft.Kind() == reflect.Struct {
+			if t, ok := fieldType(ft, name); ok {
+				return t, true
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if fieldName == "" {
+			fieldName = f.Name
+		}
+		if fieldName == name {
+			return f.Type, true
+		}
+		if fold == nil && strings.EqualFold(fieldName, name) {
+			fold = f.Type
+		}
+	}
+
+	return fold, fold != nil
+}
@@ -0,0 +1,46 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMapCoercion(t *testing.T) {
+	type role struct {
+		Name  string         `json:"name"`
+		Stats map[string]int `json:"stats"`
+	}
+	type scene struct {
+		Roles  []role            `json:"roles"`
+		Labels map[string]string `json:"labels"`
+		Tags   []string          `json:"tags"`
+	}
+
+	input := `{"roles":[{"name":"我","stats":[{"key":"hp","value":10},{"name":"mp","value":5}]},` +
+		`{"name":"DJ","stats":["hp: 7","mp=3"]}],"Labels":["mood:夜晚"],"tags":["a:1"]}`
+
+	var s scene
+	require.Nil(t, NewJSONParser(true, WithMapCoercion()).Unmarshal([]byte(input), &s))
+	require.Equal(t, scene{
+		Roles: []role{
+			{Name: "我", Stats: map[string]int{"hp": 10, "mp": 5}},
+			{Name: "DJ", Stats: map[string]int{"hp": 7, "mp": 3}},
+		},
+		Labels: map[string]string{"mood": "夜晚"},
+		Tags:   []string{"a:1"},
+	}, s)
+
+	var m map[string]any
+	require.Nil(t, NewJSONParser(false, WithMapCoercion()).FastUnmarshal([]byte(`[{"key":"a","value":1},{"key":"b","value":[2`), &m))
+	require.Equal(t, map[string]any{"a": 1.0, "b": []any{2.0}}, m)
+
+	var bad map[string]int
+	require.NotNil(t, NewJSONParser(true, WithMapCoercion()).Unmarshal([]byte(`[{"key":"a","value":1},3]`), &bad))
+	require.NotNil(t, NewJSONParser(true).Unmarshal([]byte(`["a:1"]`), &bad))
+}
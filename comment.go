@@ -0,0 +1,50 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import "strings"
+
+// WithAllowComments skips the // line comments and /* */ block comments found outside strings,
+// including a comment cut by the end of the input. Every dropped comment is reported as a repair.
+// It is also enabled by WithJSON5
+func WithAllowComments() ParserOption {
+	return func(p *JSONParser) {
+		p.comments = true
+	}
+}
+
+// skipComment skips the // or /* */ comment s starts with when comments are accepted. A comment
+// cut by the end of the input is skipped without being reported, as the rest of it may be streamed
+func (p *JSONParser) skipComment(s string) (string, bool) {
+	if !p.comments || len(s) == 0 || s[0] != '/' {
+		return s, false
+	}
+	if len(s) == 1 {
+		return "", true
+	}
+
+	end := -1
+	switch s[1] {
+	case '/':
+		if end = strings.IndexByte(s, '\n'); end >= 0 {
+			end++
+		}
+	case '*':
+		if end = strings.Index(s[2:], "*/"); end >= 0 {
+			end += 4
+		}
+	default:
+		return s, false
+	}
+
+	if end < 0 {
+		return "", true
+	}
+
+	p.repair(RepairDroppedComment, s, s[:end], "")
+	return s[end:], true
+}
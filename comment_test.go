@@ -0,0 +1,50 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAllowComments(t *testing.T) {
+	tests := []struct {
+		input, expected string
+	}{
+		{input: "/* answer */ {\"a\": 1, // the first\n\"b\": [2 /* two */, 3]} // done", expected: `{"a":1,"b":[2,3]}`},
+		{input: `{"a": "// not a comment", "b": "/* nor this */"}`, expected: `{"a":"// not a comment","b":"/* nor this */"}`},
+		{input: `{"a": 1, /* cut in the comm`, expected: `{"a":1}`},
+		{input: `{"a": 1, // cut in the comm`, expected: `{"a":1}`},
+		{input: `{"a": [1, /`, expected: `{"a":[1]}`},
+		{input: `{"a": /* value */`, expected: `{"a":null}`},
+	}
+
+	for _, test := range tests {
+		parser := NewJSONParser(true, WithAllowComments())
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+
+		data, err = parser.FastEnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+	}
+
+	var repairs []Repair
+	parser := NewJSONParser(true, WithAllowComments(), WithOnRepair(func(r Repair) {
+		repairs = append(repairs, r)
+	}))
+	_, err := parser.EnsureJSON("{\"a\": 1 // one\n, \"b\": 2 /* cut")
+	require.Nil(t, err)
+	require.Equal(t, []Repair{
+		{Offset: 8, Original: "// one\n", Replacement: "", Kind: RepairDroppedComment},
+		{Offset: 30, Original: "", Replacement: "}", Kind: RepairClosedBracket},
+	}, repairs)
+
+	_, err = NewJSONParser(true).EnsureJSON(`{"a": 1 /* c */}`)
+	require.NotNil(t, err)
+}
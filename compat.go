@@ -0,0 +1,65 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownCompatVersion is returned by every parse of a parser given an unknown compat version
+var ErrUnknownCompatVersion = errors.New("unknown compat version")
+
+// latestCompat is the compatibility version of the repair decisions made by default
+const latestCompat = "v1"
+
+// compatRules are the repair decisions frozen by a compatibility version. A release changing what
+// gets nulled, dropped or kept adds a version instead of changing the rules of the existing ones
+type compatRules struct {
+	// dropTrailingEmptyObject drops the empty object ending an array, as in [{"a":1},{}]
+	dropTrailingEmptyObject bool
+	// nullEmptyArray turns the arrays left empty into null
+	nullEmptyArray bool
+	// nullDroppedMember keeps the member whose string value is dropped with a null value, instead
+	// of leaving it out as the dropped element of an array is
+	nullDroppedMember bool
+	// danglingKeys is the policy applied to a key the input ends after without WithDanglingKeys
+	danglingKeys DanglingKeyPolicy
+	// looseStrings and strictStrings are what a string cut by the end of the input yields without
+	// WithIncompleteStringMode, in loose mode and otherwise
+	looseStrings, strictStrings IncompleteStringMode
+}
+
+// compatVersions holds the repair decisions of every compatibility version
+var compatVersions = map[string]compatRules{
+	"v1": {
+		dropTrailingEmptyObject: true,
+		nullEmptyArray:          true,
+		nullDroppedMember:       true,
+		danglingKeys:            DanglingKeyNull,
+		looseStrings:            IncompleteStringKeepUnescaped,
+		strictStrings:           IncompleteStringDrop,
+	},
+}
+
+// WithCompatVersion freezes the repair decisions, such as what gets nulled, dropped or kept, to
+// the ones of the given version, so that upgrading the library does not change the output. The
+// frozen decisions are the removal of the empty object ending an array, the nulling of the arrays
+// left empty and of the members whose cut string is dropped, the handling of a key the input ends
+// after and what a cut string yields. The options setting one of them explicitly, such as
+// WithDanglingKeys, take precedence. The latest version, used by default, is "v1". Every parse
+// fails with an error wrapping ErrUnknownCompatVersion if version is unknown
+func WithCompatVersion(version string) ParserOption {
+	return func(p *JSONParser) {
+		rules, ok := compatVersions[version]
+		if !ok {
+			p.optionErr = errors.Join(p.optionErr, fmt.Errorf("%w: %q", ErrUnknownCompatVersion, version))
+			return
+		}
+		p.compat = rules
+	}
+}
@@ -0,0 +1,44 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompatVersion(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		strict          bool
+	}{
+		{input: `{"a":[],"b":[{"c":1},{}],"d":[{`, expected: `{"a":null,"b":[{"c":1}],"d":null}`, strict: true},
+		{input: `{"a":[1,`, expected: `{"a":[1]}`, strict: true},
+		{input: `{"a":1,"b":"如`, expected: `{"a":1,"b":null}`, strict: true},
+		{input: `{"a":1,"b":"如`, expected: `{"a":1,"b":"如"}`},
+		{input: `{"a":1,"b":`, expected: `{"a":1,"b":null}`, strict: true},
+		{input: `["x","如\n`, expected: `["x"]`, strict: true},
+		{input: `["x","如\n`, expected: `["x","如\\n"]`},
+	}
+
+	for _, test := range tests {
+		expected, err := NewJSONParser(test.strict).EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, expected, test.input)
+
+		data, err := NewJSONParser(test.strict, WithCompatVersion("v1")).EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+	}
+
+	data, err := NewJSONParser(true, WithCompatVersion("v1"), WithDanglingKeys(DanglingKeyDrop)).EnsureJSON(`{"a":1,"b":`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1}`, data)
+
+	_, err = NewJSONParser(true, WithCompatVersion("v0")).EnsureJSON(`{"a":1}`)
+	require.ErrorIs(t, err, ErrUnknownCompatVersion)
+}
@@ -0,0 +1,57 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"regexp"
+	"strconv"
+)
+
+var (
+	// integerRe matches an integer literal
+	integerRe = regexp.MustCompile(`^-?[0-9]+$`)
+	// decimalCommaRe matches the comma and the fractional digits following an integer written with a decimal comma
+	decimalCommaRe = regexp.MustCompile(`^,([0-9]+)\s*([,}]|$)`)
+)
+
+// WithDecimalComma reads the numbers written with a decimal comma, as in {"price": 1,5}, as 1.5.
+// It applies to member values only, where the digits following the comma can not start the next
+// member, and not to array elements, where [1,5] is ambiguous. Every such number is reported as a repair
+func WithDecimalComma() ParserOption {
+	return func(p *JSONParser) {
+		p.decimalComma = true
+	}
+}
+
+// joinDecimalComma returns the number written with a decimal comma starting at s, value and
+// remaining being the integer parsed from s and the text following it. Other values are returned as is
+func (p *JSONParser) joinDecimalComma(s string, value any, remaining string) (any, string) {
+	if !p.decimalComma {
+		return value, remaining
+	}
+
+	literal := s[:len(s)-len(remaining)]
+	m := decimalCommaRe.FindStringSubmatchIndex(remaining)
+	if m == nil || !integerRe.MatchString(literal) {
+		return value, remaining
+	}
+
+	end := m[3]
+	numStr := literal + "." + remaining[m[2]:end]
+	p.repair(RepairDecimalComma, s, s[:len(literal)+end], numStr)
+	if end == len(remaining) {
+		// more fractional digits may follow
+		p.truncate(s, KindNumber, nil)
+	}
+
+	num, err := strconv.ParseFloat(numStr, 64)
+	if v, ok := p.literalNumber(numStr, num, err); ok {
+		return v, remaining[end:]
+	}
+
+	return num, remaining[end:]
+}
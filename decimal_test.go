@@ -0,0 +1,48 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDecimalComma(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: `{"price": 1,5, "qty": 2}`, expected: `{"price":1.5,"qty":2}`},
+		{input: `{"price":-12,75}`, expected: `{"price":-12.75}`},
+		{input: `{"scores":[1,5],"avg":3,0 }`, expected: `{"avg":3,"scores":[1,5]}`},
+		{input: `{"a":1,"b":2}`, expected: `{"a":1,"b":2}`},
+		{input: `{"a":1.5,5}`, expected: ``},
+	}
+
+	for _, test := range tests {
+		data, err := NewJSONParser(true, WithDecimalComma()).EnsureJSON(test.input)
+		if test.expected == "" {
+			require.NotNil(t, err, test.input)
+			continue
+		}
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+	}
+
+	var repairs []Repair
+	dec := NewStreamDecoder(NewJSONParser(true, WithDecimalComma(), WithOnRepair(func(r Repair) {
+		repairs = append(repairs, r)
+	})))
+	snapshot, err := dec.Feed([]byte(`{"a":"x","price":1,5`))
+	require.Nil(t, err)
+	require.Equal(t, `{"a":"x","price":1.5}`, snapshot.JSON)
+	require.Equal(t, 17, snapshot.Committed)
+	require.Equal(t, []Repair{
+		{Offset: 17, Original: "1,5", Replacement: "1.5", Kind: RepairDecimalComma},
+		{Offset: 20, Original: "", Replacement: "}", Kind: RepairClosedBracket},
+	}, repairs)
+}
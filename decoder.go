@@ -0,0 +1,69 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// Decoder reads and decodes the values of a stream like encoding/json.Decoder, tolerating the
+// truncation of the last value at the end of the stream, such as an HTTP response body cut off.
+// Values are repaired as by the RepairStream method of a parser without options, with memory
+// bounded by the size of a single value
+type Decoder struct {
+	br                    *bufio.Reader
+	offset                int
+	useNumber             bool
+	disallowUnknownFields bool
+}
+
+// NewDecoder returns a Decoder reading from r. The Decoder buffers its reads, and may read
+// data from r beyond the values requested
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{br: bufio.NewReader(r)}
+}
+
+// UseNumber causes the Decoder to unmarshal numbers into an interface value as a json.Number
+func (d *Decoder) UseNumber() {
+	d.useNumber = true
+}
+
+// DisallowUnknownFields causes the Decoder to return an error when a struct destination
+// has no field matching a key of an object
+func (d *Decoder) DisallowUnknownFields() {
+	d.disallowUnknownFields = true
+}
+
+// Decode reads the next value of the stream and stores it in v. When the stream ends inside the
+// value, the value is completed as by RepairStream. At the end of the stream, it returns io.EOF
+func (d *Decoder) Decode(v any) error {
+	var buf bytes.Buffer
+	r := &streamRepairer{w: bufio.NewWriter(&buf), offset: d.offset}
+	err := r.readValue(d.br, false)
+	d.offset = r.offset
+	if err != nil {
+		return err
+	}
+	if !r.started {
+		return io.EOF
+	}
+
+	r.finish()
+	r.w.Flush()
+
+	dec := json.NewDecoder(&buf)
+	if d.useNumber {
+		dec.UseNumber()
+	}
+	if d.disallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	return dec.Decode(v)
+}
@@ -0,0 +1,54 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDecoder(t *testing.T) {
+	type answer struct {
+		Question string   `json:"question"`
+		Options  []string `json:"options"`
+	}
+
+	dec := NewDecoder(strings.NewReader(`{"question":"如何","options":["a"]}
+ {"question":"面对","options":["b","c`))
+
+	var got []answer
+	for {
+		var a answer
+		err := dec.Decode(&a)
+		if err == io.EOF {
+			break
+		}
+		require.Nil(t, err)
+		got = append(got, a)
+	}
+	require.Equal(t, []answer{{"如何", []string{"a"}}, {"面对", []string{"b", "c"}}}, got)
+
+	dec = NewDecoder(strings.NewReader(`{"n":12345678901234567890,"x":1}`))
+	dec.UseNumber()
+	var m map[string]any
+	require.Nil(t, dec.Decode(&m))
+	require.Equal(t, json.Number("12345678901234567890"), m["n"])
+
+	dec = NewDecoder(strings.NewReader(`{"question":"q","x":1}`))
+	dec.DisallowUnknownFields()
+	require.NotNil(t, dec.Decode(&answer{}))
+
+	dec = NewDecoder(strings.NewReader(`{"a":1} {"a":,`))
+	require.Nil(t, dec.Decode(&m))
+	require.Equal(t, &ParseError{Offset: 13, Err: ErrUnexpectedToken}, dec.Decode(&m))
+
+	dec = NewDecoder(strings.NewReader(" \n"))
+	require.Equal(t, io.EOF, dec.Decode(&m))
+}
@@ -0,0 +1,72 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+var (
+	dialectsMu sync.RWMutex
+	// dialects holds the options bundled by every registered dialect
+	dialects = map[string][]ParserOption{
+		// openai-json covers the JSON mode of OpenAI models, whose outputs are valid but for the
+		// escapes cut by the end of the stream
+		"openai-json": {WithLenientEscapes()},
+		// qwen covers the Qwen models, which often emit trailing commas, unquoted keys and
+		// markdown emphasis inside string values
+		"qwen": {WithLenient(), WithLenientEscapes(), WithStripMarkdown()},
+		// deepseek covers the DeepSeek models, whose streams through some providers carry stray
+		// control characters
+		"deepseek": {WithLenient(), WithLenientEscapes(), WithGarbage(GarbageSkip)},
+		// claude covers the Anthropic models, which often wrap the answer in XML tags such as <json>
+		"claude": {WithLenient(), WithStripTags()},
+	}
+)
+
+// RegisterDialect registers the options bundled by the dialect name, replacing any dialect
+// registered under that name. It is safe for concurrent use
+func RegisterDialect(name string, opts ...ParserOption) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+
+	dialects[name] = slices.Clone(opts)
+}
+
+// Dialects returns the names of the registered dialects, sorted
+func Dialects() []string {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+
+	names := make([]string, 0, len(dialects))
+	for name := range dialects {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// WithDialect applies the options bundled by the dialect name, such as "openai-json", "qwen",
+// "deepseek" or "claude". Options following it override the ones of the dialect.
+// It panics if no dialect is registered under name
+func WithDialect(name string) ParserOption {
+	dialectsMu.RLock()
+	opts, ok := dialects[name]
+	dialectsMu.RUnlock()
+	if !ok {
+		panic(fmt.Sprintf("partialjson: unknown dialect %q", name))
+	}
+
+	return func(p *JSONParser) {
+		for _, opt := range opts {
+			opt(p)
+		}
+	}
+}
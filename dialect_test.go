@@ -0,0 +1,50 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDialect(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		input    string
+		expected string
+	}{
+		{
+			dialect:  "claude",
+			input:    `<json>{"name":"我","options":["接受",],}</json>`,
+			expected: `{"name":"我","options":["接受"]}`,
+		},
+		{
+			dialect:  "qwen",
+			input:    `{"name":"**墨镜僵尸**","age":.5`,
+			expected: `{"age":0.5,"name":"墨镜僵尸"}`,
+		},
+		{
+			dialect:  "deepseek",
+			input:    "{\"name\":\x01\"小僵尸\"}",
+			expected: `{"name":"小僵尸"}`,
+		},
+	}
+
+	for _, test := range tests {
+		s, err := NewJSONParser(false, WithDialect(test.dialect)).EnsureJSON(test.input)
+		require.Nil(t, err, test.dialect)
+		require.Equal(t, test.expected, s, test.dialect)
+	}
+
+	RegisterDialect("test-markdown", WithStripMarkdown())
+	require.Contains(t, Dialects(), "test-markdown")
+	s, err := NewJSONParser(true, WithDialect("test-markdown")).EnsureJSON("{\"n\":\"`42`\"}")
+	require.Nil(t, err)
+	require.Equal(t, `{"n":42}`, s)
+
+	require.Panics(t, func() { WithDialect("unknown") })
+}
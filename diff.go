@@ -0,0 +1,105 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"reflect"
+	"sort"
+)
+
+// ChangeKind is the kind of a change between two snapshots
+type ChangeKind int
+
+const (
+	// ChangeAdded is a member or element that appeared, or the document itself when there was none
+	ChangeAdded ChangeKind = iota
+	// ChangeUpdated is a value that changed, such as a string growing as it is streamed
+	ChangeUpdated
+)
+
+// Change is a value that appeared or changed between two snapshots
+type Change struct {
+	// Path is the path of the value in the dotted syntax, empty for the whole document
+	Path string
+	// Kind is the kind of the change
+	Kind ChangeKind
+	// Old is the previous value, nil when it was added
+	Old any
+	// New is the current value
+	New any
+}
+
+// Diff parses the partial documents prev and next, typically two successive snapshots of a stream,
+// and returns the values of next that appeared or changed, sorted by path. The members and elements
+// of containers present in both are compared one by one, so that a growing string is reported at its
+// own path rather than at the paths of its containers. An empty prev stands for no document
+func (p *JSONParser) Diff(prev, next string) ([]Change, error) {
+	var prevData any
+	if prev != "" {
+		var err error
+		if prevData, err = p.parse(prev); err != nil {
+			return nil, err
+		}
+	}
+
+	nextData, err := p.parse(next)
+	if err != nil {
+		return nil, err
+	}
+
+	if prev == "" {
+		return []Change{{Kind: ChangeAdded, New: nextData}}, nil
+	}
+
+	var changes []Change
+	diffValues(prevData, nextData, nil, func(path []any, old, val any, added bool) {
+		kind := ChangeUpdated
+		if added {
+			kind = ChangeAdded
+		}
+		changes = append(changes, Change{Path: formatPath(path), Kind: kind, Old: old, New: val})
+	})
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+
+	return changes, nil
+}
+
+// diffValues calls fn with the path, the previous and the current value of every value of next found
+// at path that differs from prev, added reporting whether it is a member or element missing from prev.
+// The members and elements of containers present in both are compared one by one
+func diffValues(prev, next any, path []any, fn func(path []any, old, val any, added bool)) {
+	switch next := next.(type) {
+	case map[string]any:
+		if prev, ok := prev.(map[string]any); ok {
+			for key, val := range next {
+				child := append(path[:len(path):len(path)], key)
+				if old, ok := prev[key]; ok {
+					diffValues(old, val, child, fn)
+				} else {
+					fn(child, nil, val, true)
+				}
+			}
+			return
+		}
+	case []any:
+		if prev, ok := prev.([]any); ok {
+			for i, val := range next {
+				child := append(path[:len(path):len(path)], i)
+				if i < len(prev) {
+					diffValues(prev[i], val, child, fn)
+				} else {
+					fn(child, nil, val, true)
+				}
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(prev, next) {
+		fn(path, prev, next, false)
+	}
+}
@@ -0,0 +1,40 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	parser := NewJSONParser(false)
+
+	changes, err := parser.Diff(`{"title":"Hel`, `{"title":"Hello","items":[{"id":1},{"id":2`)
+	require.Nil(t, err)
+	require.Equal(t, []Change{
+		{Path: "items", Kind: ChangeAdded, New: []any{map[string]any{"id": float64(1)}, map[string]any{"id": float64(2)}}},
+		{Path: "title", Kind: ChangeUpdated, Old: "Hel", New: "Hello"},
+	}, changes)
+
+	changes, err = parser.Diff(`{"items":[{"id":1}],"n":1`, `{"items":[{"id":1},{"id":2}],"n":1}`)
+	require.Nil(t, err)
+	require.Equal(t, []Change{
+		{Path: "items.1", Kind: ChangeAdded, New: map[string]any{"id": float64(2)}},
+	}, changes)
+
+	changes, err = parser.Diff(`{"a":1}`, `{"a":1}`)
+	require.Nil(t, err)
+	require.Empty(t, changes)
+
+	changes, err = parser.Diff("", `{"a":1`)
+	require.Nil(t, err)
+	require.Equal(t, []Change{{Kind: ChangeAdded, New: map[string]any{"a": float64(1)}}}, changes)
+
+	_, err = parser.Diff(`{"a":1}`, `x`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
@@ -0,0 +1,74 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"reflect"
+)
+
+// EinoReader is the minimal interface of a schema.StreamReader of the Eino framework
+type EinoReader[M any] interface {
+	Recv() (M, error)
+}
+
+// EinoStream yields the repaired snapshots of the JSON answer streamed by an Eino chat model decoded
+// into T, reader being the *schema.StreamReader[*schema.Message] returned by ChatModel.Stream or by a
+// compiled graph. The contents of the messages are accumulated and repaired with p, and the reader
+// is closed once done
+func EinoStream[T, M any](p *JSONParser, reader EinoReader[M]) iter.Seq2[T, error] {
+	messages := func(yield func(M, error) bool) {
+		if closer, ok := reader.(interface{ Close() }); ok {
+			defer closer.Close()
+		}
+
+		for {
+			msg, err := reader.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(msg, err) || err != nil {
+				return
+			}
+		}
+	}
+
+	return streamJSON[T](NewStreamDecoder(p), messages, EinoContent[M])
+}
+
+// EinoContent returns the content of an Eino message, that is msg.Content, or "" if it has none
+func EinoContent[M any](msg M) string {
+	content := field(reflect.ValueOf(msg), "Content")
+	if !content.IsValid() || content.Kind() != reflect.String {
+		return ""
+	}
+
+	return content.String()
+}
+
+// EinoParser repairs and decodes the JSON content of Eino messages. With M being *schema.Message it
+// implements the schema.MessageParser[T] interface, so that it can replace the MessageJSONParser
+// of a chain to accept the truncated or malformed JSON of models
+type EinoParser[T, M any] struct {
+	parser *JSONParser
+}
+
+// NewEinoParser creates an EinoParser repairing the contents of messages with p
+func NewEinoParser[T, M any](p *JSONParser) *EinoParser[T, M] {
+	return &EinoParser[T, M]{parser: p}
+}
+
+// Parse repairs the content of msg and decodes it into T
+func (e *EinoParser[T, M]) Parse(_ context.Context, msg M) (T, error) {
+	var v T
+	err := e.parser.Unmarshal([]byte(EinoContent(msg)), &v)
+
+	return v, err
+}
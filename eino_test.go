@@ -0,0 +1,89 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+)
+
+// einoMessage mirrors the shape of schema.Message
+type einoMessage struct {
+	Role    string
+	Content string
+}
+
+// einoReader replays messages like a schema.StreamReader
+type einoReader struct {
+	msgs   []*einoMessage
+	err    error
+	closed bool
+}
+
+func (r *einoReader) Recv() (*einoMessage, error) {
+	if len(r.msgs) == 0 {
+		return nil, r.err
+	}
+
+	msg := r.msgs[0]
+	r.msgs = r.msgs[1:]
+	return msg, nil
+}
+
+func (r *einoReader) Close() {
+	r.closed = true
+}
+
+func TestEinoStream(t *testing.T) {
+	type answer struct {
+		Question string   `json:"question"`
+		Options  []string `json:"options"`
+	}
+
+	reader := &einoReader{msgs: []*einoMessage{
+		{Role: "assistant", Content: `{"question":"如何`},
+		{Role: "assistant"},
+		{Content: `面对？","options":["接受"]}`},
+	}, err: io.EOF}
+
+	var answers []answer
+	for a, err := range EinoStream[answer](NewJSONParser(false), reader) {
+		require.Nil(t, err)
+		answers = append(answers, a)
+	}
+	require.Equal(t, []answer{
+		{Question: "如何"},
+		{Question: "如何面对？", Options: []string{"接受"}},
+	}, answers)
+	require.True(t, reader.closed)
+
+	reader = &einoReader{msgs: []*einoMessage{{Content: `{"question":"x`}}, err: errors.New("canceled")}
+	var errs []error
+	for _, err := range EinoStream[answer](NewJSONParser(false), reader) {
+		errs = append(errs, err)
+	}
+	require.Equal(t, []error{nil, reader.err}, errs)
+
+	reader = &einoReader{msgs: []*einoMessage{
+		{Content: `{"x":bad,"question":"如何`},
+		{Content: `面对？"}`},
+	}, err: io.EOF}
+	answers = answers[:0]
+	for a, err := range EinoStream[answer](NewJSONParser(false, WithLenient()), reader) {
+		require.ErrorIs(t, err, ErrUnexpectedToken)
+		answers = append(answers, a)
+	}
+	require.Equal(t, []answer{{Question: "如何"}, {Question: "如何面对？"}}, answers)
+
+	parser := NewEinoParser[answer, *einoMessage](NewJSONParser(false))
+	a, err := parser.Parse(context.Background(), &einoMessage{Content: `{"question":"如何面对？","options":["接受`})
+	require.Nil(t, err)
+	require.Equal(t, answer{Question: "如何面对？", Options: []string{"接受"}}, a)
+}
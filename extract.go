@@ -0,0 +1,70 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNoDocument is returned when a text holds no JSON object or array
+var ErrNoDocument = errors.New("no JSON document")
+
+// ExtractAll returns the repaired JSON of every object and array embedded in the text s, such as
+// a model answer mixing commentary and documents or a log, in text order. The trailing document is
+// completed if the text ends inside it. Brackets and braces of the text that do not start a
+// document the parser can repair, as in "see [note]", are skipped, as are braces not followed by
+// a member name, as in "{name}", which loose parsers would repair into an empty object.
+// ErrNoDocument is returned when the text holds none
+func (p *JSONParser) ExtractAll(s string) ([]string, error) {
+	var docs []string
+	for i := 0; i < len(s); {
+		start := strings.IndexAny(s[i:], "{[")
+		if start < 0 {
+			break
+		}
+		start += i
+		if !p.startsObject(s[start:]) {
+			i = start + 1
+			continue
+		}
+
+		end, _ := valueEnd(s[start:])
+		data, err := p.EnsureJSON(s[start : start+end])
+		if err != nil {
+			i = start + 1
+			continue
+		}
+
+		docs = append(docs, data)
+		i = start + end
+	}
+
+	if len(docs) == 0 {
+		return nil, ErrNoDocument
+	}
+
+	return docs, nil
+}
+
+// startsObject reports whether s, starting with a brace or a bracket, may start a document: an
+// array, or an object whose first member name is quoted, or bare for JSON5 parsers
+func (p *JSONParser) startsObject(s string) bool {
+	if s[0] == '[' {
+		return true
+	}
+
+	s = strings.TrimLeft(s[1:], " \t\r\n")
+	if s == "" || s[0] == '"' || s[0] == '}' {
+		return true
+	}
+	if p.singleQuotes && s[0] == '\'' {
+		return true
+	}
+
+	return p.json5 && identLen(s) > 0
+}
@@ -0,0 +1,47 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestExtractAll(t *testing.T) {
+	parser := NewJSONParser(false)
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    "Here is the first: {\"a\": 1}, and the list [1, 2] [see note]. Finally {\"b\": \"x",
+			expected: []string{`{"a":1}`, `[1,2]`, `{"b":"x"}`},
+		},
+		{
+			input:    "Use { to open objects: {\"a\": {\"b\": 2}}",
+			expected: []string{`{"a":{"b":2}}`},
+		},
+		{
+			input:    "INFO start {\"id\":1}\nWARN retry {\"id\":2,\"err\":\"timeout\"}\n",
+			expected: []string{`{"id":1}`, `{"err":"timeout","id":2}`},
+		},
+		{
+			input:    `result {"text": "a } b [ c"} done`,
+			expected: []string{`{"text":"a } b [ c"}`},
+		},
+	}
+
+	for _, test := range tests {
+		docs, err := parser.ExtractAll(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, docs, test.input)
+	}
+
+	_, err := parser.ExtractAll("no documents {here}")
+	require.ErrorIs(t, err, ErrNoDocument)
+}
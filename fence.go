@@ -0,0 +1,82 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WithExtractFromMarkdown locates the document in a model answer wrapping it in a markdown code
+// fence such as ```json and surrounding it with prose. The text before the document, up to the
+// fence if there is one, and the text after the document are dropped and reported as repairs
+func WithExtractFromMarkdown() ParserOption {
+	return func(p *JSONParser) {
+		p.extractMarkdown = true
+	}
+}
+
+// fencedStart returns the offset of the document in s, the offset of the first object or array
+// following the opening code fence if there is one. It returns len(s) when the fence is not
+// followed by a document yet, and -1 when s holds no document. A fence only opens at the start
+// of a line, outside the strings of a document started before it
+func fencedStart(s string) int {
+	first := strings.IndexAny(s, "{[")
+	if first >= 0 && strings.TrimLeftFunc(s[:first], unicode.IsSpace) == "" {
+		// the document starts s, the fences found in it being part of its strings
+		return first
+	}
+
+	inString := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case inString && s[i] == '\\':
+			i++
+		case first >= 0 && i >= first && s[i] == '"':
+			inString = !inString
+		case !inString && s[i] == '`' && strings.HasPrefix(s[i:], "```") && isLineStart(s, i):
+			if j := strings.IndexAny(s[i:], "{["); j >= 0 {
+				return i + j
+			}
+			return len(s)
+		}
+	}
+
+	return first
+}
+
+// isLineStart reports whether only spaces precede the offset i of s on its line
+func isLineStart(s string, i int) bool {
+	line := s[:i]
+	if j := strings.LastIndexByte(line, '\n'); j >= 0 {
+		line = line[j+1:]
+	}
+
+	return strings.Trim(line, " \t") == ""
+}
+
+// stripProsePrefix drops the prose and the code fence found before the document starting s
+func (p *JSONParser) stripProsePrefix(s string) string {
+	start := fencedStart(s)
+	if start <= 0 {
+		return s
+	}
+
+	p.repair(RepairStrippedFence, s, s[:start], "")
+	return s[start:]
+}
+
+// stripProseSuffix drops the closing code fence and the prose found after the document
+func (p *JSONParser) stripProseSuffix(s string) string {
+	rest := strings.TrimLeftFunc(s, unicode.IsSpace)
+	if rest == "" {
+		return s
+	}
+
+	p.repair(RepairStrippedFence, rest, strings.TrimRightFunc(rest, unicode.IsSpace), "")
+	return ""
+}
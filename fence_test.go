@@ -0,0 +1,63 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestExtractFromMarkdown(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "Here is the [requested] JSON:\n```json\n{\"a\":\"```\",\"b\":[1,2]}\n```\nHope it helps!", expected: `{"a":"` + "```" + `","b":[1,2]}`},
+		{input: "Sure! {\"a\":1} Anything else?", expected: `{"a":1}`},
+		{input: "```json\n[{\"a\":1},{\"b\":\"x", expected: `[{"a":1},{"b":null}]`},
+		{input: `{"a":1}`, expected: `{"a":1}`},
+		{input: "{\"code\":\"```go\\nfmt.Println()\\n```\"}", expected: `{"code":"` + "```go\\nfmt.Println()\\n```" + `"}`},
+		{input: "Result: {\"a\":\"x ```\",\"b\":[1]}", expected: `{"a":"x ` + "```" + `","b":[1]}`},
+	}
+
+	for _, test := range tests {
+		var extra string
+		parser := NewJSONParser(true, WithExtractFromMarkdown(), WithOnExtraToken(func(_ string, _ any, remaining string) {
+			extra = remaining
+		}))
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+		require.Empty(t, extra)
+
+		data, err = parser.FastEnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+	}
+
+	var repairs []Repair
+	parser := NewJSONParser(true, WithExtractFromMarkdown(), WithOnRepair(func(r Repair) {
+		repairs = append(repairs, r)
+	}))
+	var obj struct {
+		A int `json:"a"`
+	}
+	require.Nil(t, parser.Unmarshal([]byte("Result:\n```json\n{\"a\":3}\n```"), &obj))
+	require.Equal(t, 3, obj.A)
+	require.Equal(t, []Repair{
+		{Offset: 0, Original: "Result:\n```json\n", Replacement: "", Kind: RepairStrippedFence},
+		{Offset: 24, Original: "```", Replacement: "", Kind: RepairStrippedFence},
+	}, repairs)
+
+	_, err := parser.EnsureJSON("```json\n")
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+
+	// a fence at the start of a line of a string is part of the document
+	require.Equal(t, 8, fencedStart("Result: [\"x\\\"\n```\"]"))
+	require.Equal(t, 16, fencedStart("Result:\n```json\n{\"a\":1}"))
+	require.Equal(t, -1, fencedStart("no document"))
+}
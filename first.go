@@ -0,0 +1,67 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+// WithStopAtFirstValue stops parsing at the end of the first complete top-level value, leaving
+// the text following it, such as the commentary models add after the answer, untouched: it is
+// neither scanned by FastEnsureJSON nor repaired. Use EnsureJSONRest to get it back
+func WithStopAtFirstValue() ParserOption {
+	return func(p *JSONParser) {
+		p.stopAtFirst = true
+	}
+}
+
+// EnsureJSONRest returns a valid JSON string repaired from the first top-level value of s, as
+// EnsureJSON does with WithStopAtFirstValue, along with the untouched text following the value
+func (p *JSONParser) EnsureJSONRest(s string) (string, string, error) {
+	cp := *p
+	cp.stopAtFirst = true
+	sp := cp.session(s)
+	data, err := sp.run()
+	ret, err := sp.marshal(data, err)
+
+	return ret, sp.state.rest, err
+}
+
+// firstValueEnd returns the offset of the end of the first top-level container of s,
+// or len(s) if s ends inside it
+func firstValueEnd(s string) int {
+	end, _ := valueEnd(s)
+	return end
+}
+
+// valueEnd returns the offset of the end of the first top-level container of s and true,
+// or len(s) and false if s ends inside it
+func valueEnd(s string) (int, bool) {
+	depth := 0
+	inString := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+			if depth == 0 {
+				return i + 1, true
+			}
+		}
+	}
+
+	return len(s), false
+}
@@ -0,0 +1,42 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStopAtFirstValue(t *testing.T) {
+	input := "{\"question\":\"如何面对？\"}\n\nI chose {this} because <b>it</b> fits.\x00"
+
+	data, rest, err := NewJSONParser(true, WithLenient(), WithStripTags(), WithGarbage(GarbageFail)).EnsureJSONRest(input)
+	require.Nil(t, err)
+	require.Equal(t, `{"question":"如何面对？"}`, data)
+	require.Equal(t, "\n\nI chose {this} because <b>it</b> fits.\x00", rest)
+
+	data, rest, err = NewJSONParser(false).EnsureJSONRest(`{"question":"如何`)
+	require.Nil(t, err)
+	require.Equal(t, `{"question":"如何"}`, data)
+	require.Equal(t, "", rest)
+
+	var repairs []Repair
+	parser := NewJSONParser(true, WithStopAtFirstValue(), WithStripTags(), WithOnRepair(func(r Repair) {
+		repairs = append(repairs, r)
+	}))
+	data, err = parser.EnsureJSON(`{"a":"}"} see <b>{note}</b>`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":"}"}`, data)
+	require.Empty(t, repairs)
+
+	data, err = NewJSONParser(true, WithStopAtFirstValue()).FastEnsureJSON(`{"a":[1,2]} and {"b":`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":[1,2]}`, data)
+
+	_, err = NewJSONParser(true).FastEnsureJSON(`{"a":[1,2]} then }`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
@@ -0,0 +1,80 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import "unicode/utf8"
+
+// WithFullwidthDigits normalizes the numbers written with fullwidth digits and signs, as in
+// "age": ３０ or "delta": －１．５, to ASCII. Every normalized number is reported as a repair
+func WithFullwidthDigits() ParserOption {
+	return func(p *JSONParser) {
+		p.fullwidthDigits = true
+	}
+}
+
+// asciiNumeric returns the ASCII character standing for the character r of a number, and whether r is fullwidth
+func asciiNumeric(r rune) (byte, bool, bool) {
+	switch {
+	case '０' <= r && r <= '９':
+		return byte('0' + r - '０'), true, true
+	case r == '＋':
+		return '+', true, true
+	case r == '－' || r == '−':
+		return '-', true, true
+	case r == '．':
+		return '.', true, true
+	case r == 'ｅ':
+		return 'e', true, true
+	case r == 'Ｅ':
+		return 'E', true, true
+	case '0' <= r && r <= '9', r == '+', r == '-', r == '.', r == 'e', r == 'E':
+		return byte(r), false, true
+	}
+
+	return 0, false, false
+}
+
+// fullwidthNumber returns the length of the number s starts with and its ASCII form,
+// or 0 if it holds no fullwidth character or does not start with a digit, a sign or a point
+func fullwidthNumber(s string) (int, string) {
+	var ascii []byte
+	fullwidth := false
+	n := 0
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		c, wide, ok := asciiNumeric(r)
+		if !ok || (n == 0 && (c == '+' || c == 'e' || c == 'E')) {
+			break
+		}
+
+		fullwidth = fullwidth || wide
+		ascii = append(ascii, c)
+		n += size
+	}
+
+	if !fullwidth {
+		return 0, ""
+	}
+
+	return n, string(ascii)
+}
+
+// parseFullwidthNumber parses a number holding fullwidth characters, as parseNumber does its ASCII form
+func (p *JSONParser) parseFullwidthNumber(s string) (any, string, error) {
+	n, ascii := fullwidthNumber(s)
+	p.repair(RepairFullwidthDigits, s, s[:n], ascii)
+
+	// the ASCII form is shorter: shift the offsets computed while parsing it back onto the input
+	p.state.shift = n - len(ascii)
+	v, remaining, err := p.parseNumber(ascii + s[n:])
+	p.state.shift = 0
+	if err != nil {
+		remaining = s
+	}
+
+	return v, remaining, err
+}
@@ -0,0 +1,39 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFullwidthDigits(t *testing.T) {
+	var repairs []Repair
+	parser := NewJSONParser(true, WithFullwidthDigits(), WithLenient(), WithOnRepair(func(r Repair) {
+		repairs = append(repairs, r)
+	}))
+
+	data, err := parser.EnsureJSON(`{"age":３０,"delta":－１．５,"ratio":．５,"n":1２,"name":"１２"}`)
+	require.Nil(t, err)
+	require.Equal(t, `{"age":30,"delta":-1.5,"n":12,"name":"１２","ratio":0.5}`, data)
+	require.Equal(t, []Repair{
+		{Offset: 7, Original: "３０", Replacement: "30", Kind: RepairFullwidthDigits},
+		{Offset: 22, Original: "－１．５", Replacement: "-1.5", Kind: RepairFullwidthDigits},
+		{Offset: 43, Original: "．５", Replacement: ".5", Kind: RepairFullwidthDigits},
+		{Offset: 43, Original: ".5", Replacement: "0.5", Kind: RepairNormalizedNumber},
+		{Offset: 54, Original: "1２", Replacement: "12", Kind: RepairFullwidthDigits},
+	}, repairs)
+
+	dec := NewStreamDecoder(NewJSONParser(true, WithFullwidthDigits()))
+	snapshot, err := dec.Feed([]byte(`{"a":1,"b":１２`))
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1,"b":12}`, snapshot.JSON)
+	require.Equal(t, 11, snapshot.Committed)
+
+	_, err = NewJSONParser(true).EnsureJSON(`{"age":３０}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
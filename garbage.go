@@ -0,0 +1,107 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrBinaryGarbage is returned when binary garbage is found in the input with GarbageFail
+var ErrBinaryGarbage = errors.New("binary garbage")
+
+// GarbagePolicy controls how binary garbage, such as NUL bytes, control characters and
+// invalid UTF-8 left by a corrupted transport, is handled
+type GarbagePolicy int
+
+const (
+	// GarbageIgnore leaves the garbage to the parser, which usually fails on it. It is the default behavior
+	GarbageIgnore GarbagePolicy = iota
+	// GarbageSkip drops the garbage, skipping to the next plausible token outside strings.
+	// Every dropped run is reported as a repair
+	GarbageSkip
+	// GarbageFail fails with a *ParseError wrapping ErrBinaryGarbage at the offset of the first garbage byte
+	GarbageFail
+)
+
+// WithGarbage sets how binary garbage found in the input is handled
+func WithGarbage(policy GarbagePolicy) ParserOption {
+	return func(p *JSONParser) {
+		p.garbage = policy
+	}
+}
+
+// garbageLen returns the length of the garbage s starts with, 0 if s does not start with garbage.
+// An incomplete UTF-8 sequence at the end of the input is not garbage as the rest may be streamed
+func garbageLen(s string) int {
+	n := 0
+	for n < len(s) {
+		c := s[n]
+		if c < utf8.RuneSelf {
+			if (c < 0x20 && c != '\t' && c != '\n' && c != '\r') || c == 0x7f {
+				n++
+				continue
+			}
+			break
+		}
+
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if r != utf8.RuneError || size != 1 || !utf8.FullRuneInString(s[n:]) {
+			break
+		}
+		n++
+	}
+
+	return n
+}
+
+// garbageIndex returns the offset of the first garbage byte of s, -1 if there is none
+func garbageIndex(s string) int {
+	for i := 0; i < len(s); {
+		if garbageLen(s[i:]) > 0 {
+			return i
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+
+	return -1
+}
+
+// dropGarbage returns s without its garbage
+func dropGarbage(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if n := garbageLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		b.WriteString(s[i : i+size])
+		i += size
+	}
+
+	return b.String()
+}
+
+// skipGarbage skips the garbage s starts with when the policy is GarbageSkip
+func (p *JSONParser) skipGarbage(s string) (string, bool) {
+	if p.garbage != GarbageSkip {
+		return s, false
+	}
+
+	n := garbageLen(s)
+	if n == 0 {
+		return s, false
+	}
+
+	p.repair(RepairDroppedGarbage, s, s[:n], "")
+	return s[n:], true
+}
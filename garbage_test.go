@@ -0,0 +1,103 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGarbage(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		policy          GarbagePolicy
+		repairs         []Repair
+		offset          int
+	}{
+		{
+			input:    "{\"a\":1,\x00\x00\"b\":[1\x01,\xff2]}",
+			expected: `{"a":1,"b":[1,2]}`,
+			policy:   GarbageSkip,
+			repairs: []Repair{
+				{Offset: 7, Original: "\x00\x00", Replacement: "", Kind: RepairDroppedGarbage},
+				{Offset: 15, Original: "\x01", Replacement: "", Kind: RepairDroppedGarbage},
+				{Offset: 17, Original: "\xff", Replacement: "", Kind: RepairDroppedGarbage},
+			},
+		},
+		{
+			input:    "{\"a\":\"你\x00好\",\"b\":\"世\x7f",
+			expected: `{"a":"你好","b":"世"}`,
+			policy:   GarbageSkip,
+			repairs: []Repair{
+				{Offset: 5, Original: "\"你\x00好\"", Replacement: `"你好"`, Kind: RepairDroppedGarbage},
+				{Offset: 24, Original: "", Replacement: `"`, Kind: RepairClosedString},
+				{Offset: 24, Original: "", Replacement: "}", Kind: RepairClosedBracket},
+			},
+		},
+		{
+			input:    "{\"a\":\"你\xe5",
+			expected: "{\"a\":\"你\ufffd\"}",
+			policy:   GarbageFail,
+			repairs: []Repair{
+				{Offset: 10, Original: "", Replacement: `"`, Kind: RepairClosedString},
+				{Offset: 10, Original: "", Replacement: "}", Kind: RepairClosedBracket},
+			},
+		},
+		{
+			input:  "{\"a\":\"你\xe5\x00",
+			policy: GarbageFail,
+			offset: 9,
+		},
+		{
+			input:  "cb({\"a\":\"\x00\"})",
+			policy: GarbageFail,
+			offset: 9,
+		},
+	}
+
+	for _, test := range tests {
+		var repairs []Repair
+		parser := NewJSONParser(false, WithGarbage(test.policy), WithOnRepair(func(r Repair) {
+			repairs = append(repairs, r)
+		}))
+
+		data, err := parser.FastEnsureJSON(test.input)
+		if test.offset != 0 {
+			require.NotNil(t, err)
+			var perr *ParseError
+			require.True(t, errors.As(err, &perr))
+			require.ErrorIs(t, err, ErrBinaryGarbage)
+			require.Equal(t, test.offset, perr.Offset, test.input)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+		require.Equal(t, test.repairs, repairs)
+	}
+
+	offsets := []struct {
+		input  string
+		offset int
+	}{
+		{input: "  {\"a\":\"\x00\"}", offset: 8},
+		{input: "Here:\n```json\n{\"a\":\"\x00\"}", offset: 20},
+	}
+
+	parser := NewJSONParser(false, WithGarbage(GarbageFail), WithLenient(), WithExtractFromMarkdown())
+	for _, test := range offsets {
+		_, err := parser.EnsureJSON(test.input)
+		var perr *ParseError
+		require.ErrorAs(t, err, &perr, test.input)
+		require.ErrorIs(t, err, ErrBinaryGarbage, test.input)
+		require.Equal(t, test.offset, perr.Offset, test.input)
+	}
+
+	_, err := NewJSONParser(false).EnsureJSON("{\"a\":1,\x00\x00\"b\":2}")
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
@@ -0,0 +1,85 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"iter"
+	"reflect"
+	"strings"
+)
+
+// GeminiStream yields the repaired snapshots of the JSON answer of a streamed Gemini or Vertex AI
+// response decoded into T, responses being the sequence returned by client.Models.GenerateContentStream
+// of the Google GenAI Go SDK. The text parts of the first candidate are accumulated and repaired with p
+func GeminiStream[T, R any](p *JSONParser, responses iter.Seq2[R, error]) iter.Seq2[T, error] {
+	return streamJSON[T](NewStreamDecoder(p), responses, GeminiText[R])
+}
+
+// geminiResponse holds the members of a GenerateContentResponse used by GeminiText
+type geminiResponse struct {
+	Candidates []struct {
+		Content struct {
+			Parts []struct {
+				Text    string `json:"text"`
+				Thought bool   `json:"thought"`
+			} `json:"parts"`
+		} `json:"content"`
+	} `json:"candidates"`
+}
+
+// GeminiText returns the text of the parts of the first candidate of a streamed response, thoughts
+// excluded. The response is either a *genai.GenerateContentResponse or the raw JSON of a response of
+// the streamGenerateContent REST method, as []byte or json.RawMessage
+func GeminiText[R any](resp R) string {
+	if raw, ok := any(resp).([]byte); ok {
+		return geminiText(raw)
+	}
+	if raw, ok := any(resp).(json.RawMessage); ok {
+		return geminiText(raw)
+	}
+
+	candidates := field(reflect.ValueOf(resp), "Candidates")
+	if !candidates.IsValid() || candidates.Kind() != reflect.Slice || candidates.Len() == 0 {
+		return ""
+	}
+
+	parts := field(field(candidates.Index(0), "Content"), "Parts")
+	if !parts.IsValid() || parts.Kind() != reflect.Slice {
+		return ""
+	}
+
+	var sb strings.Builder
+	for i := 0; i < parts.Len(); i++ {
+		part := parts.Index(i)
+		if thought := field(part, "Thought"); thought.IsValid() && thought.Kind() == reflect.Bool && thought.Bool() {
+			continue
+		}
+		if text := field(part, "Text"); text.IsValid() && text.Kind() == reflect.String {
+			sb.WriteString(text.String())
+		}
+	}
+
+	return sb.String()
+}
+
+// geminiText returns the text of the first candidate of the raw JSON of a response
+func geminiText(raw []byte) string {
+	var resp geminiResponse
+	if err := json.Unmarshal(raw, &resp); err != nil || len(resp.Candidates) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, part := range resp.Candidates[0].Content.Parts {
+		if !part.Thought {
+			sb.WriteString(part.Text)
+		}
+	}
+
+	return sb.String()
+}
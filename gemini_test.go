@@ -0,0 +1,86 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// geminiPart, geminiContent, geminiCandidate and geminiChunk mirror the shape of genai.GenerateContentResponse
+type geminiPart struct {
+	Text    string
+	Thought bool
+}
+
+type geminiContent struct {
+	Parts []*geminiPart
+}
+
+type geminiCandidate struct {
+	Content *geminiContent
+}
+
+type geminiChunk struct {
+	Candidates []*geminiCandidate
+}
+
+func TestGeminiStream(t *testing.T) {
+	type answer struct {
+		Question string `json:"question"`
+	}
+
+	chunks := []*geminiChunk{
+		{Candidates: []*geminiCandidate{{Content: &geminiContent{Parts: []*geminiPart{{Text: "plan", Thought: true}, {Text: `{"quest`}}}}}},
+		{Candidates: []*geminiCandidate{{Content: &geminiContent{Parts: []*geminiPart{{Text: `ion":"如何`}}}}}},
+		{},
+		{Candidates: []*geminiCandidate{{Content: &geminiContent{Parts: []*geminiPart{{Text: `面对？"}`}}}}}},
+	}
+	responses := func(yield func(*geminiChunk, error) bool) {
+		for _, chunk := range chunks {
+			if !yield(chunk, nil) {
+				return
+			}
+		}
+		yield(nil, errors.New("quota exceeded"))
+	}
+
+	var answers []answer
+	var errs []error
+	for a, err := range GeminiStream[answer](NewJSONParser(false), responses) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		answers = append(answers, a)
+	}
+	require.Equal(t, []answer{{}, {Question: "如何"}, {Question: "如何面对？"}}, answers)
+	require.Len(t, errs, 1)
+
+	chunks = []*geminiChunk{
+		{Candidates: []*geminiCandidate{{Content: &geminiContent{Parts: []*geminiPart{{Text: `{"x":bad,"question":"如何`}}}}}},
+		{Candidates: []*geminiCandidate{{Content: &geminiContent{Parts: []*geminiPart{{Text: `面对？"}`}}}}}},
+	}
+	lenient := func(yield func(*geminiChunk, error) bool) {
+		for _, chunk := range chunks {
+			if !yield(chunk, nil) {
+				return
+			}
+		}
+	}
+	answers = answers[:0]
+	for a, err := range GeminiStream[answer](NewJSONParser(false, WithLenient()), lenient) {
+		require.ErrorIs(t, err, ErrUnexpectedToken)
+		answers = append(answers, a)
+	}
+	require.Equal(t, []answer{{Question: "如何"}, {Question: "如何面对？"}}, answers)
+
+	raw := []byte(`{"candidates":[{"content":{"parts":[{"text":"a"},{"text":"b","thought":true},{"text":"c"}]}}]}`)
+	require.Equal(t, "ac", GeminiText(raw))
+	require.Equal(t, "", GeminiText([]byte(`{}`)))
+}
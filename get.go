@@ -0,0 +1,258 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Result is the value found at a path of a partial document by Get
+type Result struct {
+	// Value is the repaired value, nil if it does not exist
+	Value any
+	// JSON is the repaired value encoded as JSON, "" if it does not exist
+	JSON string
+	// Exists reports whether the value was found. A value not found in a document still
+	// being streamed may be received later
+	Exists bool
+	// Complete reports whether the whole value was received, so that it will not change
+	Complete bool
+}
+
+// Get returns the value at path, in the dotted syntax, of the partial document s, e.g.
+// choices.0.message. The members and elements before it are skipped without being decoded,
+// the value alone being repaired. Parsers whose options rewrite the text or depend on the
+// path of the values, such as WithJSON5 or WithSkipPaths, repair the whole document instead
+func (p *JSONParser) Get(s, path string) (Result, error) {
+	return p.get(s, parsePath(path))
+}
+
+// get returns the value at the path segs of the partial document s
+func (p *JSONParser) get(s string, segs []string) (Result, error) {
+	if len(segs) > 0 && !p.rewritesText() {
+		if raw, found, complete, ok := seekPath(s, segs); ok {
+			if !found {
+				return Result{}, nil
+			}
+			if res, err := p.getRaw(raw, complete); err == nil {
+				return res, nil
+			}
+		}
+	}
+
+	data, status, err := p.ParseWithStatus(s)
+	if data == nil {
+		return Result{}, err
+	}
+
+	v, found := lookupPath(data, segs)
+	complete := status.Complete || !pathHasPrefix(status.path, segs)
+	if !found || v == nil && !complete && status.Kind != KindNull {
+		return Result{}, err
+	}
+
+	b, merr := json.Marshal(v)
+	if merr != nil {
+		return Result{}, merr
+	}
+
+	return Result{Value: v, JSON: string(b), Exists: true, Complete: complete}, err
+}
+
+// getRaw returns the result of the value whose text is raw, the text ending before the end of the
+// value if it is not complete
+func (p *JSONParser) getRaw(raw string, complete bool) (Result, error) {
+	sp := p.session(raw)
+	v, err := sp.run()
+	if err != nil {
+		return Result{}, err
+	}
+	if v == nil && !complete {
+		// the received part of the value was dropped, as a strict parser does with cut strings
+		return Result{}, nil
+	}
+
+	b, err := sp.encode(v)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{Value: v, JSON: string(b), Exists: true, Complete: complete}, nil
+}
+
+// seekPath returns the text of the value at the path segs of s, whether it was found and whether
+// the text of the value is complete, skipping the members and elements before it. It returns
+// false if s is not plain JSON up to the value
+func seekPath(s string, segs []string) (raw string, found, complete, ok bool) {
+	end := byte(0)
+	for _, seg := range segs {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if len(s) == 0 {
+			return "", false, false, true
+		}
+
+		switch s[0] {
+		case '{':
+			s, found, ok = seekMember(s[1:], seg)
+			end = '}'
+		case '[':
+			s, found, ok = seekElement(s[1:], seg)
+			end = ']'
+		case '"', '-', 't', 'f', 'n', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return "", false, false, true
+		default:
+			return "", false, false, false
+		}
+		if !found || !ok {
+			return "", false, false, ok
+		}
+	}
+
+	s = strings.TrimLeft(s, " \t\r\n")
+	if len(s) == 0 {
+		return "", false, false, true
+	}
+	rest := skipMember(s, end)
+	if len(rest) == 0 {
+		return s, true, closedValue(s), true
+	}
+
+	return strings.TrimRight(s[:len(s)-len(rest)], " \t\r\n"), true, true, true
+}
+
+// closedValue reports whether the value s starts with, which runs to the end of the input, is
+// complete: objects, arrays and strings are closed by their delimiter and literals by their last
+// letter, while a number at the very end of the input may still receive digits
+func closedValue(s string) bool {
+	body := strings.TrimRight(s, " \t\r\n")
+	switch s[0] {
+	case '"':
+		return stringEnd(body) == len(body)-1
+	case '{', '[':
+		return containerEnd(body) == len(body)-1
+	case 't', 'f', 'n':
+		return body == "true" || body == "false" || body == "null"
+	}
+
+	return len(body) < len(s)
+}
+
+// containerEnd returns the index of the delimiter closing the object or array s starts with, -1
+// if s ends first
+func containerEnd(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			n := stringEnd(s[i:])
+			if n < 0 {
+				return -1
+			}
+			i += n
+		case '{', '[':
+			depth++
+		case '}', ']':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// seekMember returns the text following the member name key in the members of an object s,
+// whether the member was found and whether the object is plain JSON up to it
+func seekMember(s, key string) (string, bool, bool) {
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if len(s) == 0 || s[0] == '}' {
+			return "", false, true
+		}
+		if s[0] != '"' {
+			return "", false, false
+		}
+
+		n := stringEnd(s)
+		if n < 0 {
+			return "", false, true
+		}
+		name := s[1:n]
+		if strings.IndexByte(name, '\\') >= 0 {
+			if err := json.Unmarshal([]byte(s[:n+1]), &name); err != nil {
+				return "", false, false
+			}
+		}
+
+		s = strings.TrimLeft(s[n+1:], " \t\r\n")
+		if len(s) == 0 {
+			return "", false, true
+		}
+		if s[0] != ':' {
+			return "", false, false
+		}
+		if name == key {
+			return s[1:], true, true
+		}
+
+		var ok bool
+		if s, ok = skipSibling(s[1:], '}'); !ok {
+			return "", false, true
+		}
+	}
+}
+
+// seekElement returns the text starting with the element at the index seg in the elements of an
+// array s, whether the element was found and whether the array is plain JSON up to it
+func seekElement(s, seg string) (string, bool, bool) {
+	index, err := strconv.Atoi(seg)
+	if err != nil || index < 0 {
+		return "", false, true
+	}
+
+	for i := 0; ; i++ {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if len(s) == 0 || s[0] == ']' {
+			return "", false, true
+		}
+		if i == index {
+			return s, true, true
+		}
+
+		var ok bool
+		if s, ok = skipSibling(s, ']'); !ok {
+			return "", false, true
+		}
+	}
+}
+
+// skipSibling skips the value s starts with and the comma following it, returning false if the
+// input ended first or the container ended with end
+func skipSibling(s string, end byte) (string, bool) {
+	rest := skipMember(s, end)
+	if len(rest) == 0 || rest[0] != ',' {
+		return "", false
+	}
+
+	return rest[1:], true
+}
+
+// stringEnd returns the index of the quote closing the string s starts with, -1 if s ends first
+func stringEnd(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,129 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	const doc = `{"id": "x", "choices": [{"index": 0, "message": {"role": "assistant", "content": "Hel`
+
+	tests := []struct {
+		input, path string
+		expected    Result
+	}{
+		{
+			input:    doc,
+			path:     "choices.0.message.role",
+			expected: Result{Value: "assistant", JSON: `"assistant"`, Exists: true, Complete: true},
+		},
+		{
+			input:    doc,
+			path:     "choices.0.message.content",
+			expected: Result{Value: "Hel", JSON: `"Hel"`, Exists: true},
+		},
+		{
+			input:    doc,
+			path:     "choices.0.message",
+			expected: Result{Value: map[string]any{"role": "assistant", "content": "Hel"}, JSON: `{"content":"Hel","role":"assistant"}`, Exists: true},
+		},
+		{
+			input:    doc,
+			path:     "choices.0.finish_reason",
+			expected: Result{},
+		},
+		{
+			input:    `[1, 2]`,
+			path:     "5",
+			expected: Result{},
+		},
+		{
+			input:    `{"a": {"b": [1, {"c": "}"}], "d": "x\"y"}, "e": [true, 12`,
+			path:     "e.1",
+			expected: Result{Value: float64(12), JSON: `12`, Exists: true},
+		},
+		{
+			input:    `{"a.b": 1, "c": 2}`,
+			path:     `a\.b`,
+			expected: Result{Value: float64(1), JSON: `1`, Exists: true, Complete: true},
+		},
+		{
+			input:    `{"a": 1}`,
+			path:     "a.b",
+			expected: Result{},
+		},
+		{
+			input:    `{"a": [1`,
+			path:     "",
+			expected: Result{Value: map[string]any{"a": []any{float64(1)}}, JSON: `{"a":[1]}`, Exists: true},
+		},
+		{
+			input:    "```json\n{\"a\": {\"b\": 1}, \"c\": \"x",
+			path:     "a",
+			expected: Result{Value: map[string]any{"b": float64(1)}, JSON: `{"b":1}`, Exists: true, Complete: true},
+		},
+	}
+
+	parser := NewJSONParser(false, WithExtractFromMarkdown())
+	for _, test := range tests {
+		res, err := parser.Get(test.input, test.path)
+		require.Nil(t, err, test.path)
+		require.Equal(t, test.expected, res, test.path)
+	}
+}
+
+func TestGetStrict(t *testing.T) {
+	tests := []struct {
+		input, path string
+		expected    Result
+	}{
+		{
+			input:    `{"a": "Hel`,
+			path:     "a",
+			expected: Result{},
+		},
+		{
+			input:    `{"a": [1, 2]`,
+			path:     "a",
+			expected: Result{Value: []any{float64(1), float64(2)}, JSON: `[1,2]`, Exists: true, Complete: true},
+		},
+		{
+			input:    `{"a": {"b": "]"} `,
+			path:     "a",
+			expected: Result{Value: map[string]any{"b": "]"}, JSON: `{"b":"]"}`, Exists: true, Complete: true},
+		},
+		{
+			input:    `{"a": [1, {"b": "x"}`,
+			path:     "a",
+			expected: Result{Value: []any{float64(1), map[string]any{"b": "x"}}, JSON: `[1,{"b":"x"}]`, Exists: true},
+		},
+		{
+			input:    `{"a": "x\"y"`,
+			path:     "a",
+			expected: Result{Value: `x"y`, JSON: `"x\"y"`, Exists: true, Complete: true},
+		},
+		{
+			input:    `{"a": true`,
+			path:     "a",
+			expected: Result{Value: true, JSON: `true`, Exists: true, Complete: true},
+		},
+		{
+			input:    `{"a": 12 `,
+			path:     "a",
+			expected: Result{Value: float64(12), JSON: `12`, Exists: true, Complete: true},
+		},
+	}
+
+	parser := NewJSONParser(true)
+	for _, test := range tests {
+		res, err := parser.Get(test.input, test.path)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, res, test.input)
+	}
+}
@@ -0,0 +1,268 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Violation is a difference between a repaired document and the schema it should follow
+type Violation struct {
+	// Path is the path of the offending value in the dotted syntax, empty for the document itself
+	Path string
+	// Message describes the violation, e.g. "must be an array of strings; got string"
+	Message string
+}
+
+// String returns the violation as a sentence such as "options must be an array of strings; got string"
+func (v Violation) String() string {
+	if v.Path == "" {
+		return "the document " + v.Message
+	}
+
+	return v.Path + " " + v.Message
+}
+
+// Violations is the list of the violations found in a document
+type Violations []Violation
+
+// String returns the violations one per line, in a form concise enough to be fed back to the
+// model that produced the document so that it corrects it
+func (vs Violations) String() string {
+	lines := make([]string, len(vs))
+	for i, v := range vs {
+		lines[i] = v.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Guardrail repairs s and compares it with target, which is either a *Schema or a value whose
+// type the document should be decoded into, returning the violations found. Members still being
+// streamed are not reported as missing
+func (p *JSONParser) Guardrail(s string, target any) (Violations, error) {
+	schema, ok := target.(*Schema)
+	if !ok {
+		schema = SchemaFor(target)
+	}
+
+	sp := p.session(s)
+	data, err := sp.run()
+	if err != nil {
+		return nil, err
+	}
+
+	var vs Violations
+	schema.validate(data, nil, sp.state.truncation, &vs)
+
+	return vs, nil
+}
+
+// SchemaFor returns the schema of the JSON encoding of the type of v, following the json struct tags.
+// Members are required unless tagged omitempty, and pointers may be null
+func SchemaFor(v any) *Schema {
+	return schemaFor(reflect.TypeOf(v), make(map[reflect.Type]bool))
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// schemaFor returns the schema of the type t, seen holding the types being described to stop on recursive types
+func schemaFor(t reflect.Type, seen map[reflect.Type]bool) *Schema {
+	if t == nil || seen[t] {
+		return &Schema{}
+	}
+
+	if t.Kind() == reflect.Pointer {
+		schema := *schemaFor(t.Elem(), seen)
+		if len(schema.Type) > 0 {
+			schema.Type = mergeTypes(schema.Type, []string{"null"})
+		}
+		return &schema
+	}
+
+	switch {
+	case reflect.PointerTo(t).Implements(jsonUnmarshalerType):
+		return &Schema{}
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		return &Schema{Type: []string{"string"}}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: []string{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: []string{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: []string{"number"}}
+	case reflect.String:
+		return &Schema{Type: []string{"string"}}
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: []string{"string"}}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+		return &Schema{Type: []string{"array"}, Items: schemaFor(t.Elem(), seen)}
+	case reflect.Map:
+		return &Schema{Type: []string{"object"}}
+	case reflect.Struct:
+		seen[t] = true
+		defer delete(seen, t)
+		schema := &Schema{Type: []string{"object"}, Properties: make(map[string]*Schema), objects: true}
+		structFields(t, schema, seen)
+		sort.Strings(schema.Required)
+		return schema
+	}
+
+	return &Schema{}
+}
+
+// structFields adds the members encoding the fields of the struct type t to schema, flattening embedded structs
+func structFields(t reflect.Type, schema *Schema, seen map[reflect.Type]bool) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			structFields(ft, schema, seen)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		schema.Properties[name] = schemaFor(f.Type, seen)
+		if !slices.Contains(strings.Split(opts, ","), "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// validate appends the violations of the value v found at path to vs
+func (s *Schema) validate(v any, path []any, tr *truncation, vs *Violations) {
+	if s == nil || len(s.Type) == 0 {
+		return
+	}
+
+	if !slices.ContainsFunc(s.Type, func(t string) bool { return matchesType(v, t) }) {
+		*vs = append(*vs, Violation{
+			Path:    formatPath(path),
+			Message: fmt.Sprintf("must be %s; got %s", s.describe(), jsonType(v)),
+		})
+		return
+	}
+
+	switch v := v.(type) {
+	case []any:
+		for i, elem := range v {
+			s.Items.validate(elem, append(path[:len(path):len(path)], i), tr, vs)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			s.Properties[key].validate(v[key], append(path[:len(path):len(path)], key), tr, vs)
+		}
+
+		if tr.covers(path) {
+			return
+		}
+		for _, key := range s.Required {
+			if _, ok := v[key]; !ok {
+				*vs = append(*vs, Violation{Path: formatPath(append(path[:len(path):len(path)], key)), Message: "is required"})
+			}
+		}
+	}
+}
+
+// describe returns the description of the values allowed by the schema, e.g. "an array of strings"
+func (s *Schema) describe() string {
+	descs := make([]string, len(s.Type))
+	for i, t := range s.Type {
+		switch t {
+		case "array":
+			descs[i] = "an array"
+			if s.Items != nil && len(s.Items.Type) == 1 {
+				descs[i] += " of " + plural(s.Items.Type[0])
+			}
+		case "object", "integer":
+			descs[i] = "an " + t
+		case "null":
+			descs[i] = t
+		default:
+			descs[i] = "a " + t
+		}
+	}
+
+	return strings.Join(descs, " or ")
+}
+
+// plural returns the plural of the name of the JSON Schema type t
+func plural(t string) string {
+	if t == "null" {
+		return "nulls"
+	}
+
+	return t + "s"
+}
+
+// matchesType reports whether the value v is of the JSON Schema type t
+func matchesType(v any, t string) bool {
+	switch t {
+	case "integer":
+		switch v := v.(type) {
+		case float64:
+			return v == math.Trunc(v)
+		case json.Number:
+			return !strings.ContainsAny(string(v), ".eE")
+		case *big.Int:
+			return true
+		}
+		return false
+	case "number":
+		return jsonType(v) == "number"
+	}
+
+	return jsonType(v) == t
+}
+
+// jsonType returns the name of the JSON Schema type of the parsed value v
+func jsonType(v any) string {
+	kind := kindOf(v)
+	if kind == KindBool {
+		return "boolean"
+	}
+
+	return kind.String()
+}
@@ -0,0 +1,75 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type guardrailScene struct {
+	Name  string `json:"name"`
+	Lines []struct {
+		Role    string  `json:"role"`
+		Content string  `json:"content"`
+		Volume  *int    `json:"volume,omitempty"`
+		Weight  float64 `json:"-"`
+	} `json:"lines"`
+}
+
+type guardrailQuestion struct {
+	Question string          `json:"question"`
+	Options  []string        `json:"options"`
+	Scene    *guardrailScene `json:"scene,omitempty"`
+	Score    int             `json:"score,omitempty"`
+}
+
+func TestGuardrail(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    `{"question":"如何面对？","options":["接受","拒绝"]}`,
+			expected: "",
+		},
+		{
+			input:    `{"question":"如何面对？","options":"接受"}`,
+			expected: "options must be an array of strings; got string",
+		},
+		{
+			input: `{"options":[1,"拒绝"],"score":1.5,"scene":{"lines":[{"role":"我","volume":"loud"}]}}`,
+			expected: "options.0 must be a string; got number\n" +
+				"scene.lines.0.volume must be an integer or null; got string\n" +
+				"scene.lines.0.content is required\n" +
+				"scene.name is required\n" +
+				"score must be an integer; got number\n" +
+				"question is required",
+		},
+		{
+			input:    `{"question":"如何面对？","scene":{"lines":[{"role":"我`,
+			expected: "",
+		},
+		{
+			input:    `["如何面对？"]`,
+			expected: "the document must be an object; got array",
+		},
+	}
+
+	p := NewJSONParser(false)
+	for _, test := range tests {
+		vs, err := p.Guardrail(test.input, guardrailQuestion{})
+		require.Nil(t, err)
+		require.Equal(t, test.expected, vs.String(), test.input)
+	}
+
+	schema, err := p.InferSchema(`{"tags":["a","b"]}`)
+	require.Nil(t, err)
+	vs, err := p.Guardrail(`{"tags":[1]}`, schema)
+	require.Nil(t, err)
+	require.Equal(t, Violations{{Path: "tags.0", Message: "must be a string; got number"}}, vs)
+}
@@ -0,0 +1,89 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Hash returns the SHA-256 digest of the canonical form of the repaired document, in which
+// members are sorted by name, whitespace is dropped and every number is written in a single
+// notation, so that snapshots differing only in formatting, member order or number notation hash
+// the same, WithKeyOrder, WithUseNumber and WithBigNumbers included
+func (p *JSONParser) Hash(s string) ([32]byte, error) {
+	canon := *p
+	canon.keyOrder = false
+	canon.useNumber = false
+	if canon.bigNumbers != BigNumberFloat {
+		// the numbers float64 can not hold exactly are kept as json.Number, then rewritten
+		canon.bigNumbers = BigNumberJSONNumber
+	}
+
+	sp := canon.session(s)
+	data, err := sp.run()
+	canonical, err := sp.marshal(canonicalNumbers(data), err)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return sha256.Sum256([]byte(canonical)), nil
+}
+
+// canonicalNumbers rewrites the json.Number values of v in the notation of canonicalNumber
+func canonicalNumbers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, elem := range v {
+			v[key] = canonicalNumbers(elem)
+		}
+	case []any:
+		for i, elem := range v {
+			v[i] = canonicalNumbers(elem)
+		}
+	case json.Number:
+		return json.Number(canonicalNumber(string(v)))
+	}
+
+	return v
+}
+
+// canonicalNumber returns the exact notation of the number literal text made of its significant
+// digits followed by an exponent if not zero, so that 1.0, 1 and 10e-1 are all written 1
+func canonicalNumber(text string) string {
+	mantissa, exp := text, 0
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		e, err := strconv.Atoi(text[i+1:])
+		if err != nil {
+			return text
+		}
+		mantissa, exp = text[:i], e
+	}
+
+	sign := ""
+	if strings.HasPrefix(mantissa, "-") {
+		sign, mantissa = "-", mantissa[1:]
+	}
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		exp -= len(mantissa) - i - 1
+		mantissa = mantissa[:i] + mantissa[i+1:]
+	}
+
+	mantissa = strings.TrimLeft(mantissa, "0")
+	if mantissa == "" {
+		return "0"
+	}
+	digits := strings.TrimRight(mantissa, "0")
+	exp += len(mantissa) - len(digits)
+	if exp == 0 {
+		return sign + digits
+	}
+
+	return sign + digits + "e" + strconv.Itoa(exp)
+}
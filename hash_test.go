@@ -0,0 +1,67 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	parser := NewJSONParser(false)
+	h1, err := parser.Hash(`{"b": [1.0, 2e0], "a": "x"}`)
+	require.Nil(t, err)
+
+	h2, err := parser.Hash("{\n  \"a\": \"x\",\n  \"b\": [1, 2]\n}")
+	require.Nil(t, err)
+	require.Equal(t, h1, h2)
+
+	h3, err := parser.Hash(`{"a":"x","b":[1,2`)
+	require.Nil(t, err)
+	require.Equal(t, h1, h3)
+
+	h4, err := parser.Hash(`{"a":"y","b":[1,2]}`)
+	require.Nil(t, err)
+	require.NotEqual(t, h1, h4)
+
+	h5, err := NewJSONParser(false, WithKeyOrder()).Hash(`{"b":[1,2],"a":"x"}`)
+	require.Nil(t, err)
+	require.Equal(t, h1, h5)
+
+	tests := []struct {
+		parser                 *JSONParser
+		input, same, different string
+	}{
+		{parser: NewJSONParser(false, WithUseNumber()), input: `{"a":[1.0,-0.50]}`, same: `{"a":[1,-5e-1]}`, different: `{"a":[1,0.5]}`},
+		{
+			parser:    NewJSONParser(false, WithBigNumbers(BigNumberBigInt)),
+			input:     `[12345678901234567890.0, 1]`,
+			same:      `[1.234567890123456789e19, 1.0]`,
+			different: `[12345678901234567891, 1]`,
+		},
+		{
+			parser:    NewJSONParser(false, WithBigNumbers(BigNumberString)),
+			input:     `[0.10000000000000000000001]`,
+			same:      `[1.0000000000000000000001E-1]`,
+			different: `["0.10000000000000000000001"]`,
+		},
+	}
+
+	for _, test := range tests {
+		h, err := test.parser.Hash(test.input)
+		require.Nil(t, err, test.input)
+		same, err := test.parser.Hash(test.same)
+		require.Nil(t, err, test.same)
+		require.Equal(t, h, same, test.same)
+		different, err := test.parser.Hash(test.different)
+		require.Nil(t, err, test.different)
+		require.NotEqual(t, h, different, test.different)
+	}
+
+	_, err = parser.Hash(`oops`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
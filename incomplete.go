@@ -0,0 +1,65 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+// IncompleteStringMode controls what a string value cut by the end of the input yields
+type IncompleteStringMode int
+
+const (
+	// IncompleteStringDrop drops the string, the member getting a null value and the element being
+	// left out. It is the behavior of strict parsers
+	IncompleteStringDrop IncompleteStringMode = iota
+	// IncompleteStringKeep keeps the decoded prefix of the string, leaving out a trailing incomplete escape
+	IncompleteStringKeep
+	// IncompleteStringKeepUnescaped keeps the prefix of the string as found in the input, escapes
+	// included but for a trailing incomplete escape. It is the behavior of non-strict parsers
+	IncompleteStringKeepUnescaped
+	// IncompleteStringEmpty replaces the string with an empty string
+	IncompleteStringEmpty
+)
+
+// WithIncompleteStringMode sets what a string value cut by the end of the input yields, independently
+// of the strictness, e.g. keeping partial prose for streaming displays with a strict parser.
+// Keys cut by the end of the input are not affected
+func WithIncompleteStringMode(mode IncompleteStringMode) ParserOption {
+	return func(p *JSONParser) {
+		p.stringMode = mode
+		p.stringModeSet = true
+	}
+}
+
+// incompleteStringMode returns what the string cut by the end of the input yields
+func (p *JSONParser) incompleteStringMode() IncompleteStringMode {
+	if p.stringModeSet && !p.state.inKey {
+		return p.stringMode
+	}
+	if p.keepsPartial() {
+		return p.compat.looseStrings
+	}
+
+	return p.compat.strictStrings
+}
+
+// incompleteStringValue returns the value of a string cut by the end of the input, raw being its
+// content as found in the input and decoded its content with the escapes of the quotes decoded
+func (p *JSONParser) incompleteStringValue(raw, decoded string) (any, string, error) {
+	mode := p.incompleteStringMode()
+	if mode != IncompleteStringDrop && !p.state.inKey {
+		p.repair(RepairClosedString, "", "", `"`)
+	}
+
+	switch mode {
+	case IncompleteStringKeep:
+		return partialString(decoded), "", nil
+	case IncompleteStringKeepUnescaped:
+		return trimPartialEscape(raw), "", nil
+	case IncompleteStringEmpty:
+		return "", "", nil
+	}
+
+	return nil, "", ErrIncompleteString
+}
@@ -0,0 +1,60 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIncompleteStringMode(t *testing.T) {
+	input := `{"a":"x","b":["say \"hi\" \u4f`
+	tests := []struct {
+		mode     IncompleteStringMode
+		expected string
+	}{
+		{mode: IncompleteStringDrop, expected: `{"a":"x","b":null}`},
+		{mode: IncompleteStringKeep, expected: `{"a":"x","b":["say \"hi\" "]}`},
+		{mode: IncompleteStringKeepUnescaped, expected: `{"a":"x","b":["say \\\"hi\\\" "]}`},
+		{mode: IncompleteStringEmpty, expected: `{"a":"x","b":[""]}`},
+	}
+
+	for _, test := range tests {
+		for _, strict := range []bool{true, false} {
+			parser := NewJSONParser(strict, WithIncompleteStringMode(test.mode))
+			data, err := parser.EnsureJSON(input)
+			require.Nil(t, err, test.mode)
+			require.Equal(t, test.expected, data, test.mode)
+
+			data, err = parser.FastEnsureJSON(input)
+			require.Nil(t, err, test.mode)
+			require.Equal(t, test.expected, data, test.mode)
+		}
+	}
+
+	data, err := NewJSONParser(true, WithIncompleteStringMode(IncompleteStringKeep)).EnsureJSON(`{"title":"Hel`)
+	require.Nil(t, err)
+	require.Equal(t, `{"title":"Hel"}`, data)
+
+	data, err = NewJSONParser(true, WithIncompleteStringMode(IncompleteStringKeep)).EnsureJSON(`{"title":"Hello","bo`)
+	require.Nil(t, err)
+	require.Equal(t, `{"title":"Hello"}`, data)
+
+	data, err = NewJSONParser(false, WithIncompleteStringMode(IncompleteStringDrop)).EnsureJSON(`{"title":"Hel`)
+	require.Nil(t, err)
+	require.Equal(t, `{"title":null}`, data)
+
+	data, err = NewJSONParser(true, WithAllowSingleQuotes(), WithIncompleteStringMode(IncompleteStringKeep)).EnsureJSON(`{'title':'it\'s`)
+	require.Nil(t, err)
+	require.Equal(t, `{"title":"it's"}`, data)
+
+	for _, input := range []string{`{"a":"x\u00`, `{"a":"x\`, `{"a":"x\ud83d`} {
+		data, err = NewJSONParser(false).EnsureJSON(input)
+		require.Nil(t, err, input)
+		require.Equal(t, `{"a":"x"}`, data, input)
+	}
+}
@@ -0,0 +1,35 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// invisibleChars are the invisible characters models and copy-paste pipelines inject between tokens:
+// zero-width space, non-joiner and joiner, word joiner, Mongolian vowel separator, soft hyphen and BOM
+const invisibleChars = "\u200b\u200c\u200d\u2060\u180e\u00ad\ufeff"
+
+// skipInvisible skips the invisible characters s starts with, reporting them as a repair
+func (p *JSONParser) skipInvisible(s string) (string, bool) {
+	n := 0
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if !strings.ContainsRune(invisibleChars, r) {
+			break
+		}
+		n += size
+	}
+
+	if n == 0 {
+		return s, false
+	}
+
+	p.repair(RepairDroppedInvisible, s, s[:n], "")
+	return s[n:], true
+}
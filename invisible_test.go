@@ -0,0 +1,29 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInvisibleChars(t *testing.T) {
+	var repairs []Repair
+	parser := NewJSONParser(true, WithOnRepair(func(r Repair) {
+		repairs = append(repairs, r)
+	}))
+
+	data, err := parser.FastEnsureJSON("\ufeff{\"a\":\u200b1,\u200c\u200d\"b\":[\u2060\"x\u200by\"]}")
+	require.Nil(t, err)
+	require.Equal(t, "{\"a\":1,\"b\":[\"x\u200by\"]}", data)
+	require.Equal(t, []Repair{
+		{Offset: 0, Original: "\ufeff", Replacement: "", Kind: RepairDroppedInvisible},
+		{Offset: 8, Original: "\u200b", Replacement: "", Kind: RepairDroppedInvisible},
+		{Offset: 13, Original: "\u200c\u200d", Replacement: "", Kind: RepairDroppedInvisible},
+		{Offset: 24, Original: "\u2060", Replacement: "", Kind: RepairDroppedInvisible},
+	}, repairs)
+}
@@ -0,0 +1,139 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrRepairBudget is returned when an input needs more repairs than allowed by WithRepairBudget
+var ErrRepairBudget = errors.New("repair budget exceeded")
+
+// RetryReason is the reason why the output of a model can not be salvaged
+type RetryReason int
+
+const (
+	// RetrySyntax is the reason of outputs broken beyond repair, such as mismatched delimiters
+	RetrySyntax RetryReason = iota
+	// RetryNotJSON is the reason of outputs holding no object or array at all
+	RetryNotJSON
+	// RetryGarbage is the reason of outputs failed with GarbageFail
+	RetryGarbage
+	// RetryRepairBudget is the reason of outputs needing more repairs than allowed by WithRepairBudget
+	RetryRepairBudget
+	// RetryPanic is the reason of outputs the parser panicked on
+	RetryPanic
+)
+
+var retryReasonNames = [...]string{
+	RetrySyntax:       "syntax",
+	RetryNotJSON:      "not json",
+	RetryGarbage:      "garbage",
+	RetryRepairBudget: "repair budget",
+	RetryPanic:        "panic",
+}
+
+// String returns the name of the reason
+func (r RetryReason) String() string {
+	if r < 0 || int(r) >= len(retryReasonNames) {
+		return "unknown"
+	}
+
+	return retryReasonNames[r]
+}
+
+// Irreparable describes why the output of a model can not be salvaged, so that the caller can
+// re-prompt the model or regenerate the output
+type Irreparable struct {
+	// Reason is the class of the failure
+	Reason RetryReason
+	// Offset is the byte offset of the failure in the input, -1 when unknown
+	Offset int
+	// Repairs is the number of repairs and skipped problems applied before the failure
+	Repairs int
+	// Err is the error returned by the parser
+	Err error
+}
+
+func (e *Irreparable) Error() string {
+	if e.Offset < 0 {
+		return fmt.Sprintf("irreparable output (%v): %v", e.Reason, e.Err)
+	}
+
+	return fmt.Sprintf("irreparable output (%v) at offset %d: %v", e.Reason, e.Offset, e.Err)
+}
+
+func (e *Irreparable) Unwrap() error {
+	return e.Err
+}
+
+// WithRepairBudget fails the inputs needing more than n repairs, counting the problems skipped
+// in lenient mode but not the completion of a truncated input, with a *ParseError wrapping
+// ErrRepairBudget at the offset of the first repair over budget. Heavily repaired outputs are
+// often better regenerated than trusted
+func WithRepairBudget(n int) ParserOption {
+	return func(p *JSONParser) {
+		p.repairBudget = n
+	}
+}
+
+// WithOnIrreparable sets a function called by EnsureJSON, and the functions built on it, with the
+// classification of every input that can not be salvaged
+func WithOnIrreparable(fn func(*Irreparable)) ParserOption {
+	return func(p *JSONParser) {
+		p.onIrreparable = fn
+	}
+}
+
+// overBudget returns the error of a session needing more repairs than allowed, nil if within budget
+func (p *JSONParser) overBudget() error {
+	st := p.state
+	repairs := st.normalized()
+	if p.repairBudget <= 0 || len(repairs)+len(st.problems) <= p.repairBudget {
+		return nil
+	}
+
+	offset := -1
+	if len(repairs) > p.repairBudget {
+		offset = repairs[p.repairBudget].Offset
+	} else {
+		var perr *ParseError
+		if errors.As(st.problems[p.repairBudget-len(repairs)], &perr) {
+			offset = perr.Offset
+		}
+	}
+
+	return &ParseError{Offset: offset, Err: ErrRepairBudget}
+}
+
+// classify returns the classification of the error err the session failed with
+func (p *JSONParser) classify(err error) *Irreparable {
+	st := p.state
+	irr := &Irreparable{Reason: RetrySyntax, Offset: -1, Repairs: len(st.normalized()) + len(st.problems), Err: err}
+
+	var perr *ParseError
+	if errors.As(err, &perr) {
+		irr.Offset = perr.Offset
+	}
+
+	var panicErr *PanicError
+	switch {
+	case errors.As(err, &panicErr):
+		irr.Reason = RetryPanic
+	case errors.Is(err, ErrRepairBudget):
+		irr.Reason = RetryRepairBudget
+	case errors.Is(err, ErrBinaryGarbage):
+		irr.Reason = RetryGarbage
+	case !strings.ContainsAny(st.input, "{["):
+		irr.Reason = RetryNotJSON
+		irr.Offset = 0
+	}
+
+	return irr
+}
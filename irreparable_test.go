@@ -0,0 +1,63 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIrreparable(t *testing.T) {
+	tests := []struct {
+		input    string
+		opts     []ParserOption
+		expected *Irreparable
+	}{
+		{
+			input:    `Sorry, I can not help with that.`,
+			expected: &Irreparable{Reason: RetryNotJSON, Offset: 0, Err: &ParseError{Offset: 0, Line: 1, Column: 1, Token: "Sorry", Err: ErrUnexpectedToken}},
+		},
+		{
+			input:    `{"a":[1,2}`,
+			expected: &Irreparable{Reason: RetrySyntax, Offset: 9, Err: &ParseError{Offset: 9, Line: 1, Column: 10, Token: "}", Err: ErrUnexpectedToken}},
+		},
+		{
+			input:    "{\"a\":\x00}",
+			opts:     []ParserOption{WithGarbage(GarbageFail)},
+			expected: &Irreparable{Reason: RetryGarbage, Offset: 5, Err: &ParseError{Offset: 5, Line: 1, Column: 6, Token: "\x00", Err: ErrBinaryGarbage}},
+		},
+		{
+			input: `{"a":.5,"b":1.,"c":.25}`,
+			opts:  []ParserOption{WithLenient(), WithRepairBudget(2)},
+			expected: &Irreparable{
+				Reason:  RetryRepairBudget,
+				Offset:  19,
+				Repairs: 3,
+				Err:     &ParseError{Offset: 19, Line: 1, Column: 20, Token: ".25", Err: ErrRepairBudget},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var got *Irreparable
+		opts := append(test.opts, WithOnIrreparable(func(irr *Irreparable) {
+			got = irr
+		}))
+
+		_, err := NewJSONParser(true, opts...).EnsureJSON(test.input)
+		require.NotNil(t, err)
+		require.Equal(t, test.expected, got, test.input)
+		require.Equal(t, err, got.Err)
+	}
+
+	var calls int
+	p := NewJSONParser(true, WithLenient(), WithRepairBudget(2), WithOnIrreparable(func(*Irreparable) { calls++ }))
+	s, err := p.EnsureJSON(`{"a":.5,"b":1.}`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":0.5,"b":1}`, s)
+	require.Equal(t, 0, calls)
+}
@@ -0,0 +1,80 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"strconv"
+	"unicode"
+)
+
+// WithJSON5 accepts JSON5 input, as emitted by many models: unquoted keys, single-quoted strings,
+// trailing commas, hexadecimal numbers, numbers with a leading plus sign and comments. The output
+// is still RFC 8259 JSON, every rewrite being reported as a repair
+func WithJSON5() ParserOption {
+	return func(p *JSONParser) {
+		p.json5 = true
+		p.comments = true
+		p.singleQuotes = true
+		p.lenientEscapes = true
+	}
+}
+
+// bareKey returns in JSON5 mode the identifier s starts with used as a key, as in {name: 1},
+// and the remaining text
+func (p *JSONParser) bareKey(s string) (string, string, bool) {
+	if !p.json5 {
+		return "", s, false
+	}
+
+	n := identLen(s)
+	if n == 0 {
+		return "", s, false
+	}
+
+	key := s[:n]
+	p.repair(RepairQuotedKey, s, key, strconv.Quote(key))
+	return key, s[n:], true
+}
+
+// identLen returns the length of the identifier s starts with, 0 if it does not start with one
+func identLen(s string) int {
+	for i, r := range s {
+		if !isIdentRune(r) || (i == 0 && (unicode.IsDigit(r) || r == '-')) {
+			return i
+		}
+	}
+
+	return len(s)
+}
+
+// containCompleteJSON5Key reports whether the single-quoted or bare key s starts with is complete.
+// It reports false as second result when s starts with neither
+func (p *JSONParser) containCompleteJSON5Key(s string) (bool, bool) {
+	if p.singleQuotes && len(s) > 0 && s[0] == '\'' {
+		return singleQuoteEnd(s) > 0, true
+	}
+	if n := identLen(s); p.json5 && n > 0 {
+		return n < len(s), true
+	}
+
+	return false, false
+}
+
+// parsePlusNumber parses in JSON5 mode a number with a leading plus sign, as in +1
+func (p *JSONParser) parsePlusNumber(s string) (any, string, error) {
+	if len(s) > 1 && (s[1] == '+' || s[1] == '-') {
+		return nil, s, ErrUnexpectedToken
+	}
+
+	v, remaining, err := p.parseNumber(s[1:])
+	if err != nil {
+		return v, s, err
+	}
+
+	p.repair(RepairNormalizedNumber, s, "+", "")
+	return v, remaining, nil
+}
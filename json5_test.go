@@ -0,0 +1,69 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestJSON5(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		strict          bool
+	}{
+		{
+			input: `// config
+{
+  name: 'Alice', /* the user */
+  age: +30,
+  mask: 0xFF,
+  tags: ['a', "b",],
+  quote: 'it\'s "fine"',
+}`,
+			expected: `{"age":30,"mask":255,"name":"Alice","quote":"it's \"fine\"","tags":["a","b"]}`,
+			strict:   true,
+		},
+		{input: `{name: 'Ali`, expected: `{"name":null}`, strict: true},
+		{input: `{name: 'Ali`, expected: `{"name":"Ali"}`},
+		{input: `{a: 1, na`, expected: `{"a":1}`, strict: true},
+		{input: `{a: 1, na`, expected: `{"a":1}`},
+		{input: `{a: 1, /* cut comm`, expected: `{"a":1}`, strict: true},
+		{input: `{a: 1, // cut comm`, expected: `{"a":1}`, strict: true},
+		{input: `{a: +12`, expected: `{"a":12}`, strict: true},
+		{input: `{$id: .5, _x: 5.}`, expected: `{"$id":0.5,"_x":5}`, strict: true},
+	}
+
+	for _, test := range tests {
+		parser := NewJSONParser(test.strict, WithJSON5())
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+
+		data, err = parser.FastEnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+	}
+
+	var repairs []Repair
+	parser := NewJSONParser(true, WithJSON5(), WithOnRepair(func(r Repair) {
+		repairs = append(repairs, r)
+	}))
+	data, err := parser.EnsureJSON(`{a: 'x', /*c*/ b: +1}`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":"x","b":1}`, data)
+	require.Equal(t, []Repair{
+		{Offset: 1, Original: "a", Replacement: `"a"`, Kind: RepairQuotedKey},
+		{Offset: 4, Original: "'x'", Replacement: `"x"`, Kind: RepairRequotedString},
+		{Offset: 9, Original: "/*c*/", Replacement: "", Kind: RepairDroppedComment},
+		{Offset: 15, Original: "b", Replacement: `"b"`, Kind: RepairQuotedKey},
+		{Offset: 18, Original: "+", Replacement: "", Kind: RepairNormalizedNumber},
+	}, repairs)
+
+	_, err = NewJSONParser(true).EnsureJSON(`{a: 1}`)
+	require.NotNil(t, err)
+}
@@ -7,13 +7,17 @@ package partialjson
  */
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"regexp"
+	"io"
+	"math/big"
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
 var (
@@ -25,51 +29,183 @@ var (
 	ErrIncompleteNum = errors.New("incomplete num")
 )
 
-var (
-	res = []struct {
-		regexp *regexp.Regexp
-		repl   func(string) string
-	}{
-		{
-			regexp: regexp.MustCompile(`,\{\}[\]\}]+$`),
-			repl:   func(s string) string { return strings.ReplaceAll(s, ",{}", "") },
-		},
-		{
-			regexp: regexp.MustCompile(`\[\{\}\][\]\}]+$`),
-			repl:   func(s string) string { return strings.ReplaceAll(s, "[{}]", "null") },
-		},
+// ParseError is a problem found at a position of the input. It wraps the error describing the problem,
+// such as ErrUnexpectedToken, so that errors.Is can test it
+type ParseError struct {
+	// Offset is the byte offset of the problem in the input
+	Offset int
+	// Line and Column are the 1-based line and column, in characters, of the problem in the input,
+	// 0 when the error was not located, as for the errors of StreamParser and RepairStream
+	Line, Column int
+	// Token is the text found at the problem, up to the next whitespace or delimiter, empty at the end of the input
+	Token string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%v at offset %d", e.Err, e.Offset)
 	}
+
+	return fmt.Sprintf("%v at offset %d (line %d, column %d)", e.Err, e.Offset, e.Line, e.Column)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// BigNumberMode controls how numbers exceeding float64 precision are represented
+type BigNumberMode int
+
+const (
+	// BigNumberFloat rounds big numbers to float64, which is the default behavior
+	BigNumberFloat BigNumberMode = iota
+	// BigNumberJSONNumber keeps big numbers as json.Number
+	BigNumberJSONNumber
+	// BigNumberBigInt keeps big integers as *big.Int and other big numbers as json.Number
+	BigNumberBigInt
+	// BigNumberString keeps big numbers as strings holding their original literal
+	BigNumberString
 )
 
-// JSONParser is a parser for JSON data
+// JSONParser is a parser for JSON data. Its settings are fixed by the options it is created with and
+// every call parses with its own state, so a single JSONParser is safe for concurrent use by multiple
+// goroutines, such as the handlers of an HTTP server. The callbacks set with options, such as
+// WithOnRepair, are then called concurrently too
 type JSONParser struct {
-	strict       bool
-	parsers      map[rune]func(string) (any, string, error)
-	onExtraToken func(string, any, string)
+	strictness      Strictness
+	structuralOnly  bool
+	lenient         bool
+	lenientEscapes  bool
+	bigNumbers      BigNumberMode
+	useNumber       bool
+	garbage         GarbagePolicy
+	stripMarkdown   bool
+	stripTags       bool
+	samples         []arraySample
+	onSampled       func(path string, skipped int)
+	parsers         map[rune]func(*JSONParser, string) (any, string, error)
+	onExtraToken    func(string, any, string)
+	onRepair        func(Repair)
+	onProgress      func(bytesConsumed, valuesParsed int, depth int)
+	repairBudget    int
+	onIrreparable   func(*Irreparable)
+	incompleteKey   func(prefix string) string
+	danglingKeys    DanglingKeyPolicy
+	danglingKeysSet bool
+	fullwidthDigits bool
+	decimalComma    bool
+	maxKeys         int
+	maxDepth        int
+	maxInputBytes   int
+	transforms      []func(*Document) error
+	skipPaths       [][]segment
+	stopAtFirst     bool
+	coerceMaps      bool
+	scalars         bool
+	literals        []literal
+	compat          compatRules
+	keyOrder        bool
+	extractMarkdown bool
+	json5           bool
+	comments        bool
+	singleQuotes    bool
+	pythonLiterals  bool
+	allowNonFinite  bool
+	nonFinite       NonFiniteMode
+	stringMode      IncompleteStringMode
+	stringModeSet   bool
+	placeholders    map[Kind]any
+	// optionErr holds the problems of the options, returned by every parse
+	optionErr error
+	state     *parseState
+}
+
+// progressInterval is the number of values parsed between two progress reports
+const progressInterval = 1024
+
+// parseState holds the bookkeeping of a single parse
+type parseState struct {
+	input      string
+	path       []any
+	inKey      bool
+	truncation *truncation
+	repairs    []Repair
+	problems   []error
+	depth      int
+	values     int
+	// rest is the text following the top-level value
+	rest string
+	// shift is the number of bytes the text being parsed was shortened by when rewritten
+	shift int
+	// ascii reports whether the input holds only ASCII characters, which need no rune decoding
+	ascii bool
+	// keyOrders holds the member names of the objects parsed by path, in input order
+	keyOrders map[string]*keyOrder
 }
 
-// NewJSONParser creates a JSONParser
+// truncation describes the value the input ended in
+type truncation struct {
+	path    []any
+	kind    Kind
+	partial any
+	// offset is the offset of the first input byte not reflected in complete values
+	offset int
+}
+
+// NewJSONParser creates a JSONParser, non-strict being StrictnessLoose. A strict parser drops the
+// values cut by the end of the input as StrictnessStructural does, but applies the repairs of the
+// options it is given
 func NewJSONParser(strict bool, opts ...ParserOption) *JSONParser {
+	if strict {
+		parser := NewJSONParserLevel(StrictnessStructural, opts...)
+		parser.structuralOnly = false
+		return parser
+	}
+
+	return NewJSONParserLevel(StrictnessLoose, opts...)
+}
+
+// NewJSONParserLevel creates a JSONParser with the given strictness
+func NewJSONParserLevel(level Strictness, opts ...ParserOption) *JSONParser {
 	parser := &JSONParser{
-		strict:  strict,
-		parsers: make(map[rune]func(string) (any, string, error)),
+		strictness:     level,
+		structuralOnly: level == StrictnessStructural,
+		compat:         compatVersions[latestCompat],
+		parsers:        make(map[rune]func(*JSONParser, string) (any, string, error)),
+		state:          &parseState{},
 	}
 
 	for _, opt := range opts {
 		opt(parser)
 	}
-	parser.parsers[' '] = parser.parseSpace
-	parser.parsers['\r'] = parser.parseSpace
-	parser.parsers['\n'] = parser.parseSpace
-	parser.parsers['\t'] = parser.parseSpace
-	parser.parsers['['] = parser.parseArray
-	parser.parsers['{'] = parser.parseObject
-	parser.parsers['"'] = parser.parseString
-	parser.parsers['t'] = parser.parseTrue
-	parser.parsers['f'] = parser.parseFalse
-	parser.parsers['n'] = parser.parseNull
+	parser.parsers[' '] = (*JSONParser).parseSpace
+	parser.parsers['\r'] = (*JSONParser).parseSpace
+	parser.parsers['\n'] = (*JSONParser).parseSpace
+	parser.parsers['\t'] = (*JSONParser).parseSpace
+	parser.parsers['['] = (*JSONParser).parseArray
+	parser.parsers['{'] = (*JSONParser).parseObject
+	parser.parsers['"'] = (*JSONParser).parseString
+	parser.parsers['t'] = (*JSONParser).parseTrue
+	parser.parsers['f'] = (*JSONParser).parseFalse
+	parser.parsers['n'] = (*JSONParser).parseNull
 	for _, c := range "0123456789.-" {
-		parser.parsers[c] = parser.parseNumber
+		parser.parsers[c] = (*JSONParser).parseNumber
+	}
+	if parser.singleQuotes {
+		parser.parsers['\''] = (*JSONParser).parseSingleQuoted
+	}
+	if parser.json5 {
+		parser.parsers['+'] = (*JSONParser).parsePlusNumber
+	}
+	if parser.pythonLiterals {
+		for _, c := range "TFN" {
+			parser.parsers[c] = (*JSONParser).parsePythonLiteral
+		}
+	}
+	if parser.allowNonFinite {
+		parser.parsers['N'] = (*JSONParser).parseNonFinite
+		parser.parsers['I'] = (*JSONParser).parseNonFinite
 	}
 
 	return parser
@@ -92,137 +228,603 @@ func WithDefaultOnExtraToken() ParserOption {
 	}
 }
 
+// WithBigNumbers sets how numbers that can not be represented exactly by float64 are kept,
+// both in the parsed value and in the re-marshaled output
+func WithBigNumbers(mode BigNumberMode) ParserOption {
+	return func(p *JSONParser) {
+		p.bigNumbers = mode
+	}
+}
+
+// WithUseNumber keeps every number as a json.Number holding its literal instead of a float64, both in
+// the parsed value and when unmarshaling into interface values, so that integer IDs such as 64-bit
+// snowflake IDs are not rounded. Big numbers are still represented according to WithBigNumbers
+func WithUseNumber() ParserOption {
+	return func(p *JSONParser) {
+		p.useNumber = true
+	}
+}
+
+// WithLenient enables repairs of non-standard syntax frequently emitted by models,
+// such as hexadecimal, octal and binary number literals, numbers with a bare decimal point such as
+// .5 and 5., nonstandard string escapes and Unicode whitespace such as U+3000 around the document
+func WithLenient() ParserOption {
+	return func(p *JSONParser) {
+		p.lenient = true
+	}
+}
+
+// WithLenientEscapes accepts nonstandard escapes in strings, decoding \xHH escapes
+// and keeping unknown escapes such as \q literally. It is also enabled by WithLenient
+func WithLenientEscapes() ParserOption {
+	return func(p *JSONParser) {
+		p.lenientEscapes = true
+	}
+}
+
+// WithProgress sets a function called periodically while parsing, and once the parse is done,
+// with the number of input bytes consumed, the number of values parsed and the current depth.
+// It can drive progress bars and watchdogs of long-running repairs of big documents
+func WithProgress(fn func(bytesConsumed, valuesParsed int, depth int)) ParserOption {
+	return func(p *JSONParser) {
+		p.onProgress = fn
+	}
+}
+
 // Unmarshal unmarshal JSON data into a value
 func (p *JSONParser) Unmarshal(data []byte, v any) error {
-	jsonData, err := p.EnsureJSON(string(data))
-	if err != nil {
+	jsonData, err := p.forTarget(v).EnsureJSON(string(data))
+	if jsonData == "" {
 		return err
 	}
 
-	return json.Unmarshal([]byte(jsonData), v)
+	if uerr := p.decode([]byte(jsonData), v); uerr != nil {
+		return uerr
+	}
+
+	return err
+}
+
+// UnmarshalMap unmarshal JSON data into a value and also returns the repaired document as a generic
+// map, nil if it is not an object, both views being built from a single repair of data
+func (p *JSONParser) UnmarshalMap(data []byte, v any) (map[string]any, error) {
+	sp := p.forTarget(v).session(string(data))
+	value, err := sp.run()
+	jsonData, err := sp.marshal(value, err)
+	if jsonData == "" {
+		return nil, err
+	}
+
+	if uerr := p.decode([]byte(jsonData), v); uerr != nil {
+		return nil, uerr
+	}
+
+	m, _ := value.(map[string]any)
+	return m, err
+}
+
+// UnmarshalT unmarshal JSON data into a new value of type T, repaired by a strict parser with opts
+func UnmarshalT[T any](data []byte, opts ...ParserOption) (T, error) {
+	var v T
+	err := parserWith(opts).Unmarshal(data, &v)
+	return v, err
+}
+
+// defaultParser is the strict parser of the package-level functions called without options
+var defaultParser = NewJSONParser(true)
+
+// EnsureJSON returns a valid JSON string repaired from s by a strict parser with opts, as the
+// EnsureJSON method does. It keeps no state between calls and is safe for concurrent use
+func EnsureJSON(s string, opts ...ParserOption) (string, error) {
+	return parserWith(opts).EnsureJSON(s)
+}
+
+// Unmarshal unmarshal JSON data into v, repaired by a strict parser with opts, as the Unmarshal method
+// does. It keeps no state between calls and is safe for concurrent use
+func Unmarshal(data []byte, v any, opts ...ParserOption) error {
+	return parserWith(opts).Unmarshal(data, v)
+}
+
+// parserWith returns the strict parser with opts
+func parserWith(opts []ParserOption) *JSONParser {
+	if len(opts) == 0 {
+		return defaultParser
+	}
+
+	return NewJSONParser(true, opts...)
+}
+
+// decode unmarshal the repaired JSON data into v
+func (p *JSONParser) decode(data []byte, v any) error {
+	if !p.useNumber {
+		return json.Unmarshal(data, v)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
 }
 
 // FastUnmarshal unmarshal JSON data into a value
 func (p *JSONParser) FastUnmarshal(data []byte, v any) error {
-	jsonData, err := p.FastEnsureJSON(string(data))
-	if err != nil {
+	jsonData, err := p.forTarget(v).FastEnsureJSON(string(data))
+	if jsonData == "" {
 		return err
 	}
 
-	return json.Unmarshal([]byte(jsonData), v)
+	if uerr := p.decode([]byte(jsonData), v); uerr != nil {
+		return uerr
+	}
+
+	return err
 }
 
-// EnsureJSON return a valid JSON string
+// EnsureJSON return a valid JSON string. In lenient mode the problems skipped over are
+// returned joined alongside the best-effort result. When the input can not be repaired,
+// the document parsed up to the failure point is returned alongside the error
 func (p *JSONParser) EnsureJSON(s string) (string, error) {
-	data, err := p.parse(s)
-	if err != nil {
-		return "", err
+	sp := p.session(s)
+	data, err := sp.run()
+	return sp.marshal(data, err)
+}
+
+// EnsureJSONTo writes the valid JSON string EnsureJSON returns to w, without building it as a string,
+// as when relaying snapshots to an HTTP response. Errors are returned as EnsureJSON does, the
+// document parsed up to the failure point being written when the input can not be repaired
+func (p *JSONParser) EnsureJSONTo(w io.Writer, s string) error {
+	sp := p.session(s)
+	data, err := sp.run()
+	return sp.marshalTo(w, data, err)
+}
+
+// marshal returns the JSON string of the value data repaired by the session, err being the error of the parse
+func (p *JSONParser) marshal(data any, err error) (string, error) {
+	var b strings.Builder
+	err = p.marshalTo(&b, data, err)
+	return b.String(), err
+}
+
+// marshalTo writes the JSON encoding of the value data repaired by the session to w, err being the
+// error of the parse. Nothing is written when the input could not be repaired at all
+func (p *JSONParser) marshalTo(w io.Writer, data any, err error) error {
+	if err != nil && p.onIrreparable != nil {
+		p.onIrreparable(p.classify(err))
+	}
+	if err != nil && data == nil {
+		return err
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if merr := p.encodeTo(buf, data); merr != nil {
+		return merr
+	}
+	if _, werr := w.Write(buf.Bytes()); werr != nil {
+		return werr
 	}
 
-	b, err := json.Marshal(data)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	return string(b), nil
+	return p.problems()
 }
 
 // FastEnsureJSON return a valid JSON string
-func (p *JSONParser) FastEnsureJSON(s string) (ret string, err error) {
-	if len(s) == 0 {
-		err = ErrUnexpectedToken
-		return
-	}
+func (p *JSONParser) FastEnsureJSON(s string) (string, error) {
+	var b strings.Builder
+	b.Grow(len(s))
+	err := p.FastEnsureJSONTo(&b, s)
+	return b.String(), err
+}
 
+// FastEnsureJSONTo writes the valid JSON string FastEnsureJSON returns to w. The complete prefix of s
+// is written as is, without copying it into an intermediate string
+func (p *JSONParser) FastEnsureJSONTo(w io.Writer, s string) (err error) {
+	defer catchPanic(s, &err)
 	defer func() {
-		if err == nil {
-			for _, re := range res {
-				ret = re.regexp.ReplaceAllStringFunc(ret, re.repl)
-			}
-		}
+		err = locate(err, s)
 	}()
 
-	var leftDelimIndexes []int
+	if len(s) == 0 {
+		return &ParseError{Offset: 0, Err: ErrUnexpectedToken}
+	}
+	if err := p.checkSize(len(s)); err != nil {
+		return err
+	}
+
+	if p.rewritesText() || jsonpPrefix(s) > 0 || strings.ContainsAny(s, invisibleChars) ||
+		(p.scalars && s[0] != '{' && s[0] != '[') {
+		return p.EnsureJSONTo(w, s)
+	}
+	if p.stopAtFirst {
+		s = s[:firstValueEnd(s)]
+	}
+
+	return completeDelims(p, w, s)
+}
+
+// openDelim is a container left open at some point of the scan of completeDelims
+type openDelim struct {
+	// index is the offset of the delimiter opening the container
+	index int
+	// comma is the offset of the comma preceding the last element of an array, -1 before its second element
+	comma int
+}
+
+// completeDelims writes the text s to w with the containers it left open closed, repairing the
+// innermost one with EnsureJSON. The delimiters being ASCII, s is scanned by byte index without
+// decoding runes: no byte of a multi-byte UTF-8 sequence can be taken for one. When the repaired
+// container is an empty object ending an array, the compat rules of the parser are applied to
+// that array as EnsureJSON does, from the structure tracked by the scan
+func completeDelims(p *JSONParser, w io.Writer, s string) error {
+	var open []openDelim
+	// end is the offset of the end of the top-level value, -1 while it is open
+	end := -1
 	isInQuotes := false
-	src := []rune(s)
-	for i, char := range src {
-		if char == '"' && (i == 0 || src[i-1] != '\\') {
-			isInQuotes = !isInQuotes
+	for i := 0; i < len(s); i++ {
+		char := s[i]
+		if isInQuotes {
+			// the escaped character, such as the quote of \" or the backslash of \\, is skipped
+			if char == '\\' {
+				i++
+			} else if char == '"' {
+				isInQuotes = false
+			}
+			continue
+		}
+		if char == '"' {
+			isInQuotes = true
+			continue
 		}
 
-		if !isInQuotes {
-			if char == '{' || char == '[' {
-				leftDelimIndexes = append(leftDelimIndexes, i)
+		switch char {
+		case '{', '[':
+			open = append(open, openDelim{index: i, comma: -1})
+			if p.maxDepth > 0 && len(open) > p.maxDepth {
+				// the parser reports the path of the container over the limit
+				return p.EnsureJSONTo(w, s)
 			}
-
-			if char == '}' || char == ']' {
-				if len(leftDelimIndexes) == 0 || src[leftDelimIndexes[len(leftDelimIndexes)-1]] != getReverseDelim(char) {
-					err = ErrUnexpectedToken
-					return
-				}
-
-				leftDelimIndexes = leftDelimIndexes[:len(leftDelimIndexes)-1]
+		case ',':
+			if len(open) > 0 {
+				open[len(open)-1].comma = i
+			}
+		case '}', ']':
+			if len(open) == 0 || rune(s[open[len(open)-1].index]) != getReverseDelim(rune(char)) {
+				return &ParseError{Offset: i, Err: ErrUnexpectedToken}
+			}
+			open = open[:len(open)-1]
+			if len(open) == 0 && end < 0 {
+				end = i + 1
 			}
 		}
 	}
 
-	if len(leftDelimIndexes) == 0 {
-		ret = string(src)
-		return
+	if end >= 0 && strings.TrimSpace(s[end:]) != "" {
+		// the text following the top-level value, which may hold quotes and delimiters, is left to the parser
+		return p.EnsureJSONTo(w, s)
 	}
 
-	start := len(leftDelimIndexes) - 1
-	remaining := string(src[leftDelimIndexes[start]:])
-	jsonData, err := p.EnsureJSON(remaining)
+	if len(open) == 0 {
+		_, err := io.WriteString(w, s)
+		return err
+	}
+
+	start := len(open) - 1
+	innermost := open[start].index
+	jsonData, err := p.EnsureJSON(s[innermost:])
 	if err != nil {
-		return
+		return relocate(err, s, innermost)
+	}
+
+	// the completed text is the complete prefix, the repaired innermost container and the
+	// delimiters closing the containers it is nested in
+	closers := make([]byte, 0, start)
+	for i := start - 1; i >= 0; i-- {
+		closers = append(closers, byte(getReverseDelim(rune(s[open[i].index]))))
 	}
+	if start > 0 && s[open[start-1].index] == '[' && jsonData == "{}" {
+		// the repaired container is an empty object ending its array
+		return writeDropped(p, w, s[:innermost], "{}]", open[start-1], string(closers[1:]))
+	}
+
+	if _, err := io.WriteString(w, s[:innermost]); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, jsonData); err != nil {
+		return err
+	}
+	_, err = w.Write(closers)
+	return err
+}
 
-	src = append(src[:leftDelimIndexes[start]], []rune(jsonData)...)
-	leftDelimIndexes = leftDelimIndexes[:start]
-	if len(leftDelimIndexes) == 0 {
-		ret = string(src)
+// writeDropped writes to w the text s+end ending with the array arr whose last element is an empty
+// object, end holding that object, followed by rest, applying the compat rules of the parser: the
+// empty object is dropped, and the array is turned into null if it is left empty
+func writeDropped(p *JSONParser, w io.Writer, s, end string, arr openDelim, rest string) error {
+	var parts []string
+	switch {
+	case !p.compat.dropTrailingEmptyObject:
+		parts = []string{s, end, rest}
+	case arr.comma >= 0:
+		parts = []string{s[:arr.comma], "]", rest}
+	case p.compat.nullEmptyArray:
+		parts = []string{s[:arr.index], "null", rest}
+	default:
+		parts = []string{s[:arr.index], "[]", rest}
+	}
+
+	for _, part := range parts {
+		if _, err := io.WriteString(w, part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rewritesText reports whether the parser may change or must inspect the text of complete
+// values, in which case FastEnsureJSON can not copy them as is
+func (p *JSONParser) rewritesText() bool {
+	return p.lenient || p.lenientEscapes || p.bigNumbers == BigNumberString || p.garbage != GarbageIgnore ||
+		p.stripMarkdown || p.stripTags || len(p.samples) > 0 || p.fullwidthDigits ||
+		p.decimalComma || len(p.transforms) > 0 || len(p.skipPaths) > 0 || p.strictness >= StrictnessReport ||
+		p.extractMarkdown || p.json5 || p.comments || p.singleQuotes || p.pythonLiterals ||
+		p.allowNonFinite || len(p.placeholders) > 0 || p.structuralOnly
+}
+
+// decodesValidJSON reports whether valid JSON input parses to the same value as with
+// json.Unmarshal, in which case run can try it first
+func (p *JSONParser) decodesValidJSON() bool {
+	return p.bigNumbers == BigNumberFloat && p.onProgress == nil && p.garbage == GarbageIgnore && !p.stripMarkdown &&
+		len(p.samples) == 0 && p.maxKeys == 0 && p.maxDepth == 0 && len(p.skipPaths) == 0 && !p.keyOrder && !p.useNumber
+}
+
+// session returns a copy of the parser holding the state of parsing s,
+// so that a single JSONParser can serve concurrent calls
+func (p *JSONParser) session(s string) *JSONParser {
+	cp := *p
+	cp.state = &parseState{input: s, ascii: isASCII(s)}
+	return &cp
+}
+
+// offset returns the byte offset of the remaining text s in the input
+func (p *JSONParser) offset(s string) int {
+	return len(p.state.input) - len(s) - p.state.shift
+}
+
+// covers reports whether the value at path is not complete, that is whether the
+// value the input ended in is the value at path or is inside it
+func (tr *truncation) covers(path []any) bool {
+	if tr == nil || len(tr.path) < len(path) {
+		return false
+	}
+
+	for i, seg := range path {
+		if tr.path[i] != seg {
+			return false
+		}
+	}
+
+	return true
+}
+
+// truncate records that the input ended inside a value of the given kind at the current path,
+// partial being the streamed prefix of the value if any and s the text not reflected in complete
+// values. Only the innermost truncation is kept
+func (p *JSONParser) truncate(s string, kind Kind, partial any) {
+	st := p.state
+	if st.truncation != nil || st.inKey {
 		return
 	}
 
-	delims := make([]rune, 0, len(leftDelimIndexes))
-	for i := len(leftDelimIndexes) - 1; i >= 0; i-- {
-		d := leftDelimIndexes[i]
-		delims = append(delims, getReverseDelim(src[d]))
+	st.truncation = &truncation{
+		path:    append([]any(nil), st.path...),
+		kind:    kind,
+		partial: partial,
+		offset:  p.offset(s),
 	}
-	src = append(src, delims...)
+}
+
+// pushPath descends into the member or element seg of the current value
+func (p *JSONParser) pushPath(seg any) {
+	p.state.path = append(p.state.path, seg)
+}
 
-	ret = string(src)
-	return
+// popPath returns to the parent of the current value
+func (p *JSONParser) popPath() {
+	p.state.path = p.state.path[:len(p.state.path)-1]
 }
 
 // parse parses a JSON string
 func (p *JSONParser) parse(s string) (any, error) {
+	sp := p.session(s)
+	data, err := sp.run()
+	if err != nil {
+		return nil, err
+	}
+
+	return data, sp.problems()
+}
+
+// run parses the input of the session. On error, the value parsed up to the failure point is returned alongside it
+func (p *JSONParser) run() (data any, err error) {
+	s := p.state.input
+	defer catchPanic(s, &err)
+	defer func() {
+		err = locate(err, p.state.input)
+	}()
+
+	if p.optionErr != nil {
+		return nil, p.optionErr
+	}
 	if len(s) == 0 {
-		return nil, ErrUnexpectedToken
+		return nil, &ParseError{Offset: 0, Err: ErrUnexpectedToken}
+	}
+	if err := p.checkSize(len(s)); err != nil {
+		return nil, err
+	}
+	if data, err := p.checkRFC(s); err != nil || data != nil {
+		if err != nil {
+			return nil, err
+		}
+		p.report()
+		return p.transform(data)
+	}
+
+	if p.stripTags || p.lenient || p.comments {
+		s = p.trimSpace(s)
+	} else {
+		s, _ = p.skipInvisible(s)
+	}
+
+	if p.extractMarkdown {
+		s = p.stripProsePrefix(s)
 	}
 
-	if !(strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[")) {
-		return nil, ErrUnexpectedToken
+	jsonp := jsonpPrefix(s)
+	if jsonp > 0 {
+		p.repair(RepairStrippedJSONP, s, s[:jsonp], "")
+		s = s[jsonp:]
 	}
 
-	if strings.HasSuffix(s, "}") || strings.HasSuffix(s, "]") {
+	if !p.startsDocument(s) {
+		return nil, &ParseError{Offset: p.offset(s), Err: ErrUnexpectedToken}
+	}
+
+	if p.garbage == GarbageFail {
+		scan := s
+		if p.stopAtFirst {
+			scan = s[:firstValueEnd(s)]
+		}
+		if i := garbageIndex(scan); i >= 0 {
+			return nil, &ParseError{Offset: p.offset(s[i:]), Err: ErrBinaryGarbage}
+		}
+	}
+
+	if p.decodesValidJSON() && (strings.HasSuffix(s, "}") || strings.HasSuffix(s, "]")) {
 		data := make(map[string]any)
 		err := json.Unmarshal([]byte(s), &data)
 		if err == nil {
-			return data, nil
+			p.report()
+			return p.transform(data)
 		}
 	}
 
 	data, reminding, err := p.parseAny(s)
+	p.state.rest = reminding
+	if p.stopAtFirst {
+		reminding = ""
+	}
+	if p.stripTags || p.lenient || p.comments {
+		reminding = p.trimSpace(reminding)
+	}
+	if jsonp > 0 {
+		reminding = p.stripJSONPSuffix(reminding)
+	}
+	if p.extractMarkdown {
+		reminding = p.stripProseSuffix(reminding)
+	}
+	if p.onProgress != nil {
+		p.onProgress(p.offset(reminding), p.state.values, 0)
+	}
 	if p.onExtraToken != nil && reminding != "" {
 		p.onExtraToken(s, data, reminding)
 	}
+	if berr := p.overBudget(); berr != nil {
+		return nil, berr
+	}
+	if serr := p.structuralError(); serr != nil {
+		return nil, serr
+	}
 	if err != nil {
-		return nil, err
+		if data == nil {
+			return nil, err
+		}
+
+		// the value parsed up to the failure point is transformed as a complete value is
+		tdata, terr := p.transform(data)
+		if terr != nil {
+			return nil, terr
+		}
+		return tdata, err
 	}
 
-	return data, nil
+	p.report()
+	return p.transform(data)
+}
+
+// problems returns the problems skipped over by the session joined, nil if there were none
+func (p *JSONParser) problems() error {
+	for _, problem := range p.state.problems {
+		locate(problem, p.state.input)
+	}
+
+	return errors.Join(p.state.problems...)
+}
+
+// resync records err found at s as a problem and skips to the next member of the container
+// closed by end. It reports false when the error can not be skipped, that is when the parser
+// is not lenient or the input ended inside the broken member
+func (p *JSONParser) resync(s string, err error, end byte) (string, bool) {
+	if !p.lenient || p.state.truncation != nil {
+		return s, false
+	}
+
+	rest := skipMember(s, end)
+	if len(rest) == 0 {
+		return s, false
+	}
+
+	var perr *ParseError
+	if errors.As(err, &perr) {
+		err = perr.Err
+	}
+	p.state.problems = append(p.state.problems, &ParseError{Offset: p.offset(s), Err: err})
+	s = p.trimSpace(rest)
+	if strings.HasPrefix(s, ",") {
+		s = p.trimSpace(s[1:])
+	}
+
+	return s, true
+}
+
+// skipMember returns s from the comma or the end delimiter ending the member s starts in
+func skipMember(s string, end byte) string {
+	depth := 0
+	inString := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+		case '}', ']':
+			if depth == 0 {
+				if c == end {
+					return s[i:]
+				}
+				return ""
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				return s[i:]
+			}
+		}
+	}
+
+	return ""
 }
 
 func getReverseDelim(char int32) int32 {
@@ -247,55 +849,127 @@ func (p *JSONParser) parseAny(s string) (any, string, error) {
 	}
 
 	parser, exists := p.parsers[rune(s[0])]
+	if p.fullwidthDigits && !p.state.inKey {
+		if n, _ := fullwidthNumber(s); n > 0 {
+			parser, exists = (*JSONParser).parseFullwidthNumber, true
+		}
+	}
+	if p.lenient && len(p.literals) > 0 && !p.state.inKey {
+		if _, complete, ok := p.matchLiteral(s); ok && (complete || !exists) {
+			parser, exists = (*JSONParser).parseLiteral, true
+		}
+	}
 	if !exists {
-		return nil, s, ErrUnexpectedToken
+		return nil, s, &ParseError{Offset: p.offset(s), Err: ErrUnexpectedToken}
+	}
+
+	v, remaining, err := parser(p, s)
+	if err == errCutLiteral && p.state.depth == 0 {
+		err, remaining = ErrUnexpectedToken, s
+	}
+	if err == ErrUnexpectedToken || err == ErrIncompleteNum {
+		err = &ParseError{Offset: p.offset(remaining), Err: err}
 	}
+	if p.onProgress != nil && err == nil && !p.state.inKey && !unicode.IsSpace(rune(s[0])) {
+		p.state.values++
+		if p.state.values%progressInterval == 0 {
+			p.onProgress(p.offset(remaining), p.state.values, p.state.depth)
+		}
+	}
+
+	return v, remaining, err
+}
 
-	return parser(s)
+// enter descends into a container
+func (p *JSONParser) enter() {
+	p.state.depth++
+}
+
+// exit returns from a container
+func (p *JSONParser) exit() {
+	p.state.depth--
 }
 
 func (p *JSONParser) parseSpace(s string) (any, string, error) {
-	return p.parseAny(strings.TrimSpace(s))
+	return p.parseAny(p.trimSpace(s))
 }
 
 func (p *JSONParser) parseArray(s string) (any, string, error) {
+	p.enter()
+	defer p.exit()
+	if err := p.checkDepth(s); err != nil {
+		return nil, s, err
+	}
+
 	s = s[1:]
 	var acc []any
-	s = strings.TrimSpace(s)
+	s = p.trimSpace(s)
 	var err error
+	closed := false
 
+	limit, sampled := p.sampleLimit()
 	for len(s) > 0 {
 		if s[0] == ']' {
 			s = s[1:]
+			closed = true
+			break
+		}
+
+		if sampled && len(acc) >= limit {
+			var skipped int
+			s, skipped, closed = p.skipElements(s)
+			if p.onSampled != nil {
+				p.onSampled(formatPath(p.state.path), skipped)
+			}
 			break
 		}
 
 		var remaining string
 		var res any
+		p.pushPath(len(acc))
 		res, remaining, err = p.parseAny(s)
+		if err != nil || res == nil {
+			if v, ok := p.placeholder(); ok {
+				res, remaining, err = v, "", nil
+			}
+		}
+		p.popPath()
 		if err != nil {
-			if errors.Is(err, ErrIncompleteString) {
+			if errors.Is(err, ErrIncompleteString) || err == errCutLiteral {
+				p.repair(RepairDroppedTrailingMember, s, s, "")
 				err = nil
+			} else if rest, ok := p.resync(s, err, ']'); ok {
+				s, err = rest, nil
+				continue
+			} else if res != nil {
+				acc = append(acc, res)
 			}
 
-			s = strings.TrimSpace(remaining)
+			s = p.trimSpace(remaining)
 			break
 		}
 
 		acc = append(acc, res)
-		s = strings.TrimSpace(remaining)
+		s = p.skipStrayQuote(p.trimSpace(remaining))
 		if strings.HasPrefix(s, ",") {
-			s = strings.TrimSpace(s[1:])
+			s = p.skipComma(s, ']')
+		} else if missesComma(s) {
+			p.repair(RepairInsertedComma, s, "", ",")
 		}
 	}
 
-	if len(acc) > 0 {
+	if !closed && err == nil && len(s) == 0 {
+		p.repair(RepairClosedBracket, s, "", "]")
+		p.truncate(s, KindArray, nil)
+	}
+
+	if len(acc) > 0 && p.compat.dropTrailingEmptyObject {
 		if val, ok := acc[len(acc)-1].(map[string]any); ok && len(val) == 0 {
 			acc = acc[:len(acc)-1]
 		}
 	}
 
-	if len(acc) == 0 {
+	if len(acc) == 0 && p.compat.nullEmptyArray {
 		return nil, s, err
 	}
 
@@ -303,128 +977,466 @@ func (p *JSONParser) parseArray(s string) (any, string, error) {
 }
 
 func (p *JSONParser) parseObject(s string) (any, string, error) {
+	p.enter()
+	defer p.exit()
+	if err := p.checkDepth(s); err != nil {
+		return nil, s, err
+	}
+
 	s = s[1:]
 	acc := make(map[string]any)
-	s = strings.TrimSpace(s)
+	s = p.trimSpace(s)
 	var err error
+	closed := false
 
 	for len(s) > 0 {
 		if s[0] == '}' {
 			s = s[1:]
+			closed = true
 			break
 		}
 
-		if !p.strict && !p.containCompleteKey(s) {
+		if (p.keepsPartial() || p.json5) && !p.containCompleteKey(s) {
+			if !p.emitIncompleteKey(s, acc) {
+				p.repair(RepairDroppedTrailingMember, s, s, "")
+				p.truncate(s, KindObject, nil)
+			}
+			s = ""
 			break
 		}
 
 		var key any
 		var remaining string
-		key, remaining, err = p.parseAny(s)
+		keyStart := s
+		p.state.inKey = true
+		if bare, rest, ok := p.unquotedKey(s); ok {
+			key, remaining = bare, rest
+		} else if bare, rest, ok := p.bareKey(s); ok {
+			key, remaining = bare, rest
+		} else {
+			key, remaining, err = p.parseAny(s)
+		}
+		p.state.inKey = false
 		if err != nil {
 			if errors.Is(err, ErrIncompleteString) {
+				p.repair(RepairDroppedTrailingMember, s, s, "")
+				p.truncate(s, KindObject, nil)
 				err = nil
+			} else if rest, ok := p.resync(s, err, '}'); ok {
+				s, err = rest, nil
+				continue
 			}
 
-			s = strings.TrimSpace(remaining)
+			s = p.trimSpace(remaining)
 			break
 		}
 		keyStr, ok := key.(string)
 		if !ok {
-			s = strings.TrimSpace(remaining)
+			if rest, ok := p.resync(s, ErrUnexpectedToken, '}'); ok {
+				s = rest
+				continue
+			}
+
+			s = p.trimSpace(remaining)
 			err = ErrUnexpectedToken
 			break
 		}
+		if err = p.checkKeys(keyStart, keyStr, acc); err != nil {
+			s = keyStart
+			break
+		}
+		p.noteKey(keyStr)
 
-		s = strings.TrimSpace(remaining)
+		s = p.trimSpace(remaining)
 		if len(s) == 0 || s[0] == '}' {
-			acc[keyStr] = nil
+			err = p.danglingKey(keyStart, s, keyStr, acc)
 			break
 		}
 		if s[0] != ':' {
+			if rest, ok := p.resync(s, ErrUnexpectedToken, '}'); ok {
+				s = rest
+				continue
+			}
+
 			err = ErrUnexpectedToken
 			break
 		}
-		s = strings.TrimSpace(s[1:]) // skip ':'
+		s = p.trimSpace(s[1:]) // skip ':'
 		if len(s) == 0 || s[0] == '}' {
-			acc[keyStr] = nil
+			err = p.danglingKey(keyStart, s, keyStr, acc)
 			break
 		}
 
 		var value any
+		p.pushPath(keyStr)
+		if p.skipsPath() {
+			s = p.skipValue(s)
+			p.popPath()
+			continue
+		}
 		value, remaining, err = p.parseAny(s)
+		if err == nil {
+			value, remaining = p.joinDecimalComma(s, value, remaining)
+		}
+		if err != nil || value == nil {
+			if v, ok := p.placeholder(); ok {
+				value, remaining, err = v, "", nil
+			}
+		}
+		p.popPath()
 		if err != nil {
-			if errors.Is(err, ErrIncompleteString) {
+			if err == errCutLiteral {
+				p.repair(RepairDroppedTrailingMember, keyStart, keyStart, "")
+				err = nil
+			} else if errors.Is(err, ErrIncompleteString) && !p.compat.nullDroppedMember {
+				p.repair(RepairDroppedTrailingMember, keyStart, keyStart, "")
+				err = nil
+			} else if errors.Is(err, ErrIncompleteString) {
+				p.repair(RepairDroppedTrailingMember, s, s, "null")
 				acc[keyStr] = nil
 				err = nil
+			} else if rest, ok := p.resync(s, err, '}'); ok {
+				s, err = rest, nil
+				continue
+			} else if value != nil {
+				acc[keyStr] = value
 			}
 
-			s = strings.TrimSpace(remaining)
+			s = p.trimSpace(remaining)
 			break
 		}
 
 		acc[keyStr] = value
-		s = strings.TrimSpace(remaining)
+		s = p.skipStrayQuote(p.trimSpace(remaining))
 		if strings.HasPrefix(s, ",") {
-			s = strings.TrimSpace(s[1:])
+			s = p.skipComma(s, '}')
+		} else if missesComma(s) {
+			p.repair(RepairInsertedComma, s, "", ",")
 		}
 	}
 
+	if !closed && err == nil && len(s) == 0 {
+		p.repair(RepairClosedBracket, s, "", "}")
+		p.truncate(s, KindObject, nil)
+	}
+
 	return acc, s, err
 }
 
+// skipComma skips the comma s starts with and the space following it, recording the removal of
+// a trailing comma when the container ends with end right after it
+func (p *JSONParser) skipComma(s string, end byte) string {
+	rest := p.trimSpace(s[1:])
+	if len(rest) > 0 && rest[0] == end {
+		p.repair(RepairDroppedTrailingComma, s, ",", "")
+	}
+
+	return rest
+}
+
+// missesComma reports whether the text s following a member or an element starts another one
+// without the comma separating them
+func missesComma(s string) bool {
+	return len(s) > 0 && strings.IndexByte("]}:", s[0]) < 0
+}
+
+// unquotedKey re-quotes in lenient mode a key missing its opening quote, as in {name": 1},
+// returning the key and the remaining text
+func (p *JSONParser) unquotedKey(s string) (string, string, bool) {
+	if !p.lenient {
+		return "", s, false
+	}
+
+	end := strings.IndexByte(s, '"')
+	if end <= 0 {
+		return "", s, false
+	}
+
+	key := s[:end]
+	for i, r := range key {
+		if !isIdentRune(r) || (i == 0 && unicode.IsDigit(r)) {
+			return "", s, false
+		}
+	}
+
+	rest := strings.TrimLeftFunc(s[end+1:], unicode.IsSpace)
+	if len(rest) > 0 && rest[0] != ':' {
+		return "", s, false
+	}
+
+	p.repair(RepairQuotedKey, s, s[:end+1], strconv.Quote(key))
+	return key, s[end+1:], true
+}
+
+// isIdentRune reports whether r can be part of an unquoted key
+func isIdentRune(r rune) bool {
+	return r == '_' || r == '$' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// truncateMember records the truncation of the value of member key when the input ended before it started
+func (p *JSONParser) truncateMember(s, key string) {
+	if len(s) > 0 {
+		return
+	}
+
+	p.pushPath(key)
+	p.truncate(s, KindUnknown, nil)
+	p.popPath()
+}
+
 func (p *JSONParser) containCompleteKey(s string) bool {
 	s = strings.TrimSpace(s)
+	if complete, ok := p.containCompleteJSON5Key(s); ok {
+		return complete
+	}
 
-	end := strings.Index(s[1:], "\"") + 1
-	for end > 0 && s[end-1] == '\\' {
-		if nextEnd := strings.Index(s[end+1:], "\""); nextEnd >= 0 {
-			end = nextEnd + end + 1
-		} else {
-			return false
+	return stringEnd(s) > 0
+}
+
+func (p *JSONParser) parseString(s string) (any, string, error) {
+	// the quote of \" is escaped, the one of \\" closes the string
+	end := stringEnd(s)
+	if end < 0 {
+		return p.incompleteString(s)
+	}
+	if p.lenient && strings.TrimSpace(s[1:end]) == "" && !p.endsString(s[end+1:]) {
+		// a blank string nothing can follow, as in ""value", starts with a stray quote
+		p.repair(RepairDroppedQuote, s, s[:end], "")
+		return p.parseString(s[end:])
+	}
+	strVal := s[:end+1]
+	if p.garbage == GarbageSkip && garbageIndex(strVal) >= 0 {
+		cleaned := dropGarbage(strVal)
+		p.repair(RepairDroppedGarbage, s, strVal, cleaned)
+		strVal = cleaned
+	}
+
+	var result string
+	var err error
+	if content := strVal[1 : len(strVal)-1]; p.isPlainString(content) {
+		// without escapes the value is the content itself, shared with the input rather than decoded into a copy
+		result = content
+	} else {
+		err = json.Unmarshal([]byte(strVal), &result)
+	}
+	if err != nil && (p.lenient || p.lenientEscapes) {
+		result = unescapeLenient(strVal[1 : len(strVal)-1])
+		p.repair(RepairNormalizedEscape, s, strVal, strconv.Quote(result))
+		err = nil
+	}
+	if p.stripMarkdown && err == nil && !p.state.inKey {
+		if v, ok := p.markdownValue(result); ok {
+			b, _ := json.Marshal(v)
+			p.repair(RepairStrippedMarkdown, s, strVal, string(b))
+			return v, s[end+1:], nil
 		}
 	}
+	return result, s[end+1:], err
+}
 
-	if end == 0 {
-		return false
+// isPlainString reports whether the content of a string literal holds neither escapes, control
+// characters nor invalid UTF-8, in which case it decodes to itself
+func (p *JSONParser) isPlainString(content string) bool {
+	for i := 0; i < len(content); i++ {
+		if c := content[i]; c == '\\' || c < 0x20 {
+			return false
+		}
 	}
 
-	return true
+	return p.state.ascii || utf8.ValidString(content)
 }
 
-func (p *JSONParser) parseString(s string) (any, string, error) {
-	end := strings.Index(s[1:], "\"") + 1
-	for end > 0 && s[end-1] == '\\' {
-		if nextEnd := strings.Index(s[end+1:], "\""); nextEnd >= 0 {
-			end = nextEnd + end + 1
+// trimSpace returns s without its leading whitespace, and without the leading
+// noise the parser is configured to skip such as binary garbage and markup tags
+func (p *JSONParser) trimSpace(s string) string {
+	for {
+		var skipped, ok bool
+		if p.state.ascii {
+			s = trimASCIISpace(s)
 		} else {
-			if !p.strict {
-				return s[1:], "", nil
+			s = strings.TrimLeftFunc(s, unicode.IsSpace)
+			if s, ok = p.skipInvisible(s); ok {
+				skipped = true
 			}
-			return nil, "", ErrIncompleteString
 		}
+		if s, ok = p.skipGarbage(s); ok {
+			skipped = true
+		}
+		if s, ok = p.skipTag(s); ok {
+			skipped = true
+		}
+		if s, ok = p.skipComment(s); ok {
+			skipped = true
+		}
+		if !skipped {
+			return s
+		}
+	}
+}
+
+// endsString reports whether the remaining text s can follow a string
+func (p *JSONParser) endsString(s string) bool {
+	s = strings.TrimLeftFunc(s, unicode.IsSpace)
+	return len(s) == 0 || strings.IndexByte(",:]}", s[0]) >= 0
+}
+
+// skipStrayQuote skips a stray quote found between members in lenient mode,
+// as in "value"" or 1" followed by a comma or the end of the container
+func (p *JSONParser) skipStrayQuote(s string) string {
+	if !p.lenient || !strings.HasPrefix(s, "\"") {
+		return s
+	}
+
+	rest := strings.TrimLeftFunc(s[1:], unicode.IsSpace)
+	if len(rest) == 0 || strings.IndexByte(",]}", rest[0]) < 0 {
+		return s
+	}
+
+	p.repair(RepairDroppedQuote, s, "\"", "")
+	return p.trimSpace(s[1:])
+}
+
+// incompleteString handles a string the input ended in
+func (p *JSONParser) incompleteString(s string) (any, string, error) {
+	raw := s[1:]
+	if p.garbage == GarbageSkip {
+		raw = dropGarbage(raw)
 	}
 
-	if end == 0 {
-		if !p.strict {
-			return s[1:], "", nil
+	p.truncate(s, KindString, partialString(raw))
+	return p.incompleteStringValue(raw, raw)
+}
+
+// partialString decodes the content of a truncated string literal, leaving out a trailing incomplete escape
+func partialString(raw string) string {
+	return unescapeLenient(trimPartialEscape(raw))
+}
+
+// trimPartialEscape returns the content of a truncated string literal without its trailing incomplete
+// escape, as in \u00, and without a trailing high surrogate escape whose low surrogate was cut, so that
+// neither decodes to a wrong or replacement character
+func trimPartialEscape(raw string) string {
+	if i := lastEscape(raw); i >= 0 && len(raw)-i < 6 && (i == len(raw)-1 || raw[i+1] == 'u') {
+		raw = raw[:i]
+	}
+	if i := lastEscape(raw); i >= 0 && len(raw)-i == 6 && raw[i+1] == 'u' {
+		if r, err := strconv.ParseUint(raw[i+2:], 16, 32); err == nil && 0xd800 <= r && r < 0xdc00 {
+			raw = raw[:i]
 		}
-		return nil, "", ErrIncompleteString
 	}
-	strVal := s[:end+1]
-	s = s[end+1:]
 
-	var result string
-	err := json.Unmarshal([]byte(strVal), &result)
-	return result, s, err
+	return raw
+}
+
+// lastEscape returns the index of the backslash starting the last escape of the content of a string
+// literal if it starts one of its last 6 bytes, -1 otherwise
+func lastEscape(raw string) int {
+	i := strings.LastIndexByte(raw, '\\')
+	if i < 0 || len(raw)-i > 6 {
+		return -1
+	}
+
+	j := i
+	for j > 0 && raw[j-1] == '\\' {
+		j--
+	}
+	if (i-j)%2 != 0 {
+		// the backslash is escaped
+		return -1
+	}
+
+	return i
+}
+
+// unescapeLenient decodes the content of a string literal, accepting \xHH escapes and
+// raw control characters, and keeping unknown escapes such as \q as they are
+func unescapeLenient(raw string) string {
+	buf, hasHexEscape := appendUnescaped(nil, raw, false)
+	// \xHH escapes are usually the bytes of UTF-8 sequences, otherwise they stand for code points
+	if hasHexEscape && !utf8.Valid(buf) {
+		buf, _ = appendUnescaped(buf[:0], raw, true)
+	}
+
+	return string(buf)
+}
+
+func appendUnescaped(buf []byte, raw string, hexAsRune bool) ([]byte, bool) {
+	hasHexEscape := false
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		if c != '\\' || i+1 >= len(raw) {
+			buf = append(buf, c)
+			continue
+		}
+
+		i++
+		switch raw[i] {
+		case '"', '\\', '/':
+			buf = append(buf, raw[i])
+		case 'b':
+			buf = append(buf, '\b')
+		case 'f':
+			buf = append(buf, '\f')
+		case 'n':
+			buf = append(buf, '\n')
+		case 'r':
+			buf = append(buf, '\r')
+		case 't':
+			buf = append(buf, '\t')
+		case 'u':
+			if i+4 < len(raw) {
+				if r, err := strconv.ParseUint(raw[i+1:i+5], 16, 32); err == nil {
+					r1 := rune(r)
+					i += 4
+					if utf16.IsSurrogate(r1) && i+6 < len(raw) && raw[i+1] == '\\' && raw[i+2] == 'u' {
+						if r2, err := strconv.ParseUint(raw[i+3:i+7], 16, 32); err == nil {
+							if dec := utf16.DecodeRune(r1, rune(r2)); dec != unicode.ReplacementChar {
+								r1 = dec
+								i += 6
+							}
+						}
+					}
+					buf = utf8.AppendRune(buf, r1)
+					continue
+				}
+			}
+			buf = append(buf, '\\', 'u')
+		case 'x':
+			if i+2 < len(raw) {
+				if b, err := strconv.ParseUint(raw[i+1:i+3], 16, 8); err == nil {
+					if hexAsRune {
+						buf = utf8.AppendRune(buf, rune(b))
+					} else {
+						buf = append(buf, byte(b))
+					}
+					hasHexEscape = true
+					i += 2
+					continue
+				}
+			}
+			buf = append(buf, '\\', 'x')
+		default:
+			buf = append(buf, '\\', raw[i])
+		}
+	}
+
+	return buf, hasHexEscape
 }
 
 func (p *JSONParser) parseNumber(s string) (any, string, error) {
+	if p.allowNonFinite && strings.HasPrefix(s, "-I") {
+		return p.parseNonFinite(s)
+	}
+
 	i := 0
 	if i < len(s) && s[i] == '-' {
 		i++
 	}
 
+	if (p.lenient || p.json5) && i+1 < len(s) && s[i] == '0' && strings.ContainsRune("xXoObB", rune(s[i+1])) {
+		return p.parseRadixNumber(s, i)
+	}
+
 	hasDigits := false
 	for i < len(s) && unicode.IsDigit(rune(s[i])) {
 		hasDigits = true
@@ -440,6 +1452,7 @@ func (p *JSONParser) parseNumber(s string) (any, string, error) {
 	}
 
 	if !hasDigits {
+		p.truncateNumber(s, i)
 		return nil, s, ErrIncompleteNum
 	}
 
@@ -454,14 +1467,29 @@ func (p *JSONParser) parseNumber(s string) (any, string, error) {
 			i++
 		}
 		if !hasExponent {
+			p.truncateNumber(s, i)
 			return nil, s, ErrIncompleteNum
 		}
 	}
 
 	numStr := s[:i]
 	remaining := s[i:]
+	p.truncateNumber(s, i)
+	if strings.HasPrefix(strings.TrimPrefix(numStr, "-"), ".") || strings.HasSuffix(numStr, ".") || strings.Contains(numStr, ".e") || strings.Contains(numStr, ".E") {
+		// a bare decimal point is only normalized in lenient mode, but for a trailing one cut by the end of the input
+		if !p.lenient && !p.json5 {
+			if remaining != "" || strings.HasPrefix(strings.TrimPrefix(numStr, "-"), ".") {
+				return nil, s, ErrIncompleteNum
+			}
+		} else {
+			p.repairNumber(s, numStr)
+		}
+	}
 
 	num, err := strconv.ParseFloat(numStr, 64)
+	if v, ok := p.literalNumber(numStr, num, err); ok {
+		return v, remaining, nil
+	}
 	if err != nil {
 		return nil, s, ErrIncompleteNum
 	}
@@ -469,10 +1497,123 @@ func (p *JSONParser) parseNumber(s string) (any, string, error) {
 	return num, remaining, nil
 }
 
+// truncateNumber records the truncation of the number starting at s when the input ended at s[i]
+func (p *JSONParser) truncateNumber(s string, i int) {
+	if i == len(s) {
+		p.truncate(s, KindNumber, nil)
+	}
+}
+
+// repairNumber records the normalization of a number literal with a bare leading or trailing decimal point
+func (p *JSONParser) repairNumber(s, numStr string) {
+	f, err := strconv.ParseFloat(numStr, 64)
+	if err != nil {
+		return
+	}
+
+	p.repair(RepairNormalizedNumber, s, numStr, strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// parseRadixNumber parses a hexadecimal, octal or binary literal whose prefix starts at s[i]
+func (p *JSONParser) parseRadixNumber(s string, i int) (any, string, error) {
+	base := 16
+	switch s[i+1] {
+	case 'o', 'O':
+		base = 8
+	case 'b', 'B':
+		base = 2
+	}
+
+	start := i + 2
+	end := start
+	for end < len(s) && isRadixDigit(s[end], base) {
+		end++
+	}
+	p.truncateNumber(s, end)
+	if end == start {
+		return nil, s, ErrIncompleteNum
+	}
+
+	n, ok := new(big.Int).SetString(s[start:end], base)
+	if !ok {
+		return nil, s, ErrIncompleteNum
+	}
+	if i > 0 {
+		n.Neg(n)
+	}
+
+	numStr := n.String()
+	p.repair(RepairRadixNumber, s, s[:end], numStr)
+	num, err := strconv.ParseFloat(numStr, 64)
+	if v, ok := p.literalNumber(numStr, num, err); ok {
+		return v, s[end:], nil
+	}
+
+	return num, s[end:], nil
+}
+
+func isRadixDigit(c byte, base int) bool {
+	switch {
+	case c >= '0' && c <= '1':
+		return true
+	case c >= '2' && c <= '7':
+		return base >= 8
+	case c >= '8' && c <= '9':
+		return base == 16
+	case c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+		return base == 16
+	}
+
+	return false
+}
+
+// literalNumber returns the value of the number literal numStr when it is not represented by
+// the float64 num, parsed with err, that is when it is a big number or when WithUseNumber is set
+func (p *JSONParser) literalNumber(numStr string, num float64, err error) (any, bool) {
+	if p.bigNumbers != BigNumberFloat && (err != nil || !isExactFloat(numStr, num)) {
+		return p.bigNumber(numStr), true
+	}
+	if p.useNumber && (err == nil || errors.Is(err, strconv.ErrRange)) {
+		return json.Number(numStr), true
+	}
+
+	return nil, false
+}
+
+// bigNumber represents a number literal exceeding float64 precision according to the big number mode
+func (p *JSONParser) bigNumber(numStr string) any {
+	switch p.bigNumbers {
+	case BigNumberBigInt:
+		if n, ok := new(big.Int).SetString(numStr, 10); ok {
+			return n
+		}
+	case BigNumberString:
+		return numStr
+	}
+
+	return json.Number(numStr)
+}
+
+// isExactFloat reports whether num represents the number literal numStr without precision loss
+func isExactFloat(numStr string, num float64) bool {
+	exact, ok := new(big.Rat).SetString(numStr)
+	if !ok {
+		return false
+	}
+
+	rounded, ok := new(big.Rat).SetString(strconv.FormatFloat(num, 'g', -1, 64))
+	if !ok {
+		return false
+	}
+
+	return exact.Cmp(rounded) == 0
+}
+
 func (p *JSONParser) parseTrue(s string) (any, string, error) {
 	if strings.HasPrefix(s, "true") {
 		return true, s[4:], nil
 	}
+	p.truncateLiteral(s, "true", KindBool)
 	return nil, s, ErrUnexpectedToken
 }
 
@@ -480,6 +1621,7 @@ func (p *JSONParser) parseFalse(s string) (any, string, error) {
 	if strings.HasPrefix(s, "false") {
 		return false, s[5:], nil
 	}
+	p.truncateLiteral(s, "false", KindBool)
 	return nil, s, ErrUnexpectedToken
 }
 
@@ -487,9 +1629,17 @@ func (p *JSONParser) parseNull(s string) (any, string, error) {
 	if strings.HasPrefix(s, "null") {
 		return nil, s[4:], nil
 	}
+	p.truncateLiteral(s, "null", KindNull)
 	return nil, s, ErrUnexpectedToken
 }
 
+// truncateLiteral records the truncation of literal when the input ended inside it
+func (p *JSONParser) truncateLiteral(s, literal string, kind Kind) {
+	if strings.HasPrefix(literal, s) {
+		p.truncate(s, kind, nil)
+	}
+}
+
 func (p *JSONParser) defaultOnExtraToken(text string, data any, remaining string) {
 	fmt.Printf("Parsed JSON with extra tokens. text: %s, data: %v, remaining: %s\n", text, data, remaining)
 }
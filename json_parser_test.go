@@ -8,8 +8,14 @@ package partialjson
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/require"
+	"io"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -97,7 +103,11 @@ func TestParseArray(t *testing.T) {
 
 	for _, test := range tests {
 		obj, _, err := parser.parseArray(test.input)
-		require.Equal(t, test.err, err)
+		if test.err == nil {
+			require.Nil(t, err)
+		} else {
+			require.ErrorIs(t, err, test.err)
+		}
 
 		if err == nil {
 			expected, ok1 := test.expected.([]interface{})
@@ -151,6 +161,11 @@ func TestParseString(t *testing.T) {
 			expected: "你好，\\\"世界\\\"。",
 			strict:   false,
 		},
+		{
+			input:    "\"a\xffb\"",
+			expected: "a\ufffdb",
+			strict:   true,
+		},
 	}
 
 	for _, test := range tests {
@@ -208,6 +223,131 @@ func TestParseNum(t *testing.T) {
 	}
 }
 
+func TestBigNumbers(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		mode            BigNumberMode
+	}{
+		{
+			input:    `{"id":12345678901234567890,"score":1.5`,
+			expected: `{"id":12345678901234567000,"score":1.5}`,
+			mode:     BigNumberFloat,
+		},
+		{
+			input:    `{"id":12345678901234567890,"score":1.5`,
+			expected: `{"id":12345678901234567890,"score":1.5}`,
+			mode:     BigNumberJSONNumber,
+		},
+		{
+			input:    `{"id":12345678901234567890,"ratio":0.12345678901234567890123`,
+			expected: `{"id":12345678901234567890,"ratio":0.12345678901234567890123}`,
+			mode:     BigNumberBigInt,
+		},
+		{
+			input:    `{"id":12345678901234567890,"huge":1e400,"score":1.5`,
+			expected: `{"huge":"1e400","id":"12345678901234567890","score":1.5}`,
+			mode:     BigNumberString,
+		},
+		{
+			input:    `[{"id":12345678901234567890},{"id":9007199254740993`,
+			expected: `[{"id":"12345678901234567890"},{"id":"9007199254740993"}]`,
+			mode:     BigNumberString,
+		},
+	}
+
+	for _, test := range tests {
+		parser := NewJSONParser(true, WithBigNumbers(test.mode))
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+
+		fastData, err := parser.FastEnsureJSON(test.input)
+		require.Nil(t, err)
+		require.JSONEq(t, test.expected, fastData)
+	}
+
+	parser := NewJSONParser(true, WithBigNumbers(BigNumberBigInt))
+	obj, _, err := parser.parseNumber("12345678901234567890")
+	require.Nil(t, err)
+	require.IsType(t, &big.Int{}, obj)
+	require.Equal(t, "12345678901234567890", obj.(*big.Int).String())
+}
+
+func TestUseNumber(t *testing.T) {
+	parser := NewJSONParser(true, WithUseNumber(), WithLenient())
+
+	var m map[string]any
+	require.Nil(t, parser.Unmarshal([]byte(`{"id":1790112233445566778,"score":1.50,"hex":0x1F,"ids":[9007199254740993,`), &m))
+	require.Equal(t, map[string]any{
+		"id":    json.Number("1790112233445566778"),
+		"score": json.Number("1.50"),
+		"hex":   json.Number("31"),
+		"ids":   []any{json.Number("9007199254740993")},
+	}, m)
+
+	m = nil
+	require.Nil(t, parser.Unmarshal([]byte(`{"id":1790112233445566778}`), &m))
+	require.Equal(t, map[string]any{"id": json.Number("1790112233445566778")}, m)
+
+	data, err := parser.EnsureJSON(`{"id":1790112233445566778,"score":1.50`)
+	require.Nil(t, err)
+	require.Equal(t, `{"id":1790112233445566778,"score":1.50}`, data)
+
+	obj, _, err := NewJSONParser(true, WithUseNumber(), WithBigNumbers(BigNumberBigInt)).parseNumber("12345678901234567890")
+	require.Nil(t, err)
+	require.IsType(t, &big.Int{}, obj)
+}
+
+func TestRadixNumbers(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		lenient         bool
+		err             error
+	}{
+		{
+			input: `{"mask":0xFF`,
+			err:   ErrUnexpectedToken,
+		},
+		{
+			input:    `{"mask":0xFF,"mode":0o17,"flags":0b1010,"neg":-0x10`,
+			expected: `{"flags":10,"mask":255,"mode":15,"neg":-16}`,
+			lenient:  true,
+		},
+		{
+			input:    `[0XfF, 0B11]`,
+			expected: `[255,3]`,
+			lenient:  true,
+		},
+		{
+			input:   `[0XfF, 0x`,
+			lenient: true,
+			err:     ErrIncompleteNum,
+		},
+	}
+
+	for _, test := range tests {
+		var opts []ParserOption
+		if test.lenient {
+			opts = append(opts, WithLenient())
+		}
+		parser := NewJSONParser(true, opts...)
+		data, err := parser.EnsureJSON(test.input)
+		if test.err == nil {
+			require.Nil(t, err, test.input)
+		} else {
+			require.ErrorIs(t, err, test.err, test.input)
+		}
+
+		if err == nil {
+			require.Equal(t, test.expected, data)
+
+			fastData, err := parser.FastEnsureJSON(test.input)
+			require.Nil(t, err)
+			require.Equal(t, test.expected, fastData)
+		}
+	}
+}
+
 func TestParseTrue(t *testing.T) {
 	parser := NewJSONParser(true)
 
@@ -323,19 +463,28 @@ func TestEnsureJson(t *testing.T) {
 			strict: true,
 		},
 		{
-			input:  `{"options":["\"是我自己清晰的脸\"", abc`,
-			err:    ErrUnexpectedToken,
-			strict: true,
+			input:    `{"options":["\"是我自己清晰的脸\"", abc`,
+			expected: `{"options":["\"是我自己清晰的脸\""]}`,
+			err:      ErrUnexpectedToken,
+			strict:   true,
 		},
 		{
-			input:  `{"options":["\"是我自己清晰的脸\""], 123`,
-			err:    ErrUnexpectedToken,
-			strict: true,
+			input:    `{"options":["\"是我自己清晰的脸\""], 123`,
+			expected: `{"options":["\"是我自己清晰的脸\""]}`,
+			err:      ErrUnexpectedToken,
+			strict:   true,
 		},
 		{
-			input:  `{"options",["\"是我自己清晰的脸\""], 123`,
-			err:    ErrUnexpectedToken,
-			strict: true,
+			input:    `{"roles":[{"role_name":"我"},{"role_name":"墨镜僵尸","age":x}],"question":"?"}`,
+			expected: `{"roles":[{"role_name":"我"},{"role_name":"墨镜僵尸"}]}`,
+			err:      ErrUnexpectedToken,
+			strict:   true,
+		},
+		{
+			input:    `{"options",["\"是我自己清晰的脸\""], 123`,
+			expected: `{}`,
+			err:      ErrUnexpectedToken,
+			strict:   true,
 		},
 		{
 			input:    `["是我自己清晰的脸","是初中`,
@@ -372,11 +521,12 @@ func TestEnsureJson(t *testing.T) {
 	for _, test := range tests {
 		parser := NewJSONParser(test.strict)
 		data, err := parser.EnsureJSON(test.input)
-		require.Equal(t, test.err, err, test.input+test.expected)
-
-		if err == nil {
-			require.Equal(t, test.expected, data)
+		if test.err == nil {
+			require.Nil(t, err, test.input+test.expected)
+		} else {
+			require.ErrorIs(t, err, test.err, test.input+test.expected)
 		}
+		require.Equal(t, test.expected, data)
 	}
 }
 
@@ -418,6 +568,44 @@ func TestUnmarshal(t *testing.T) {
 	}
 }
 
+func TestUnmarshalMap(t *testing.T) {
+	parser := NewJSONParser(true)
+
+	obj := testObject{}
+	m, err := parser.UnmarshalMap([]byte(`{"question":"如何面对？","options":["接受"],"extra":{"trace_id":"x1"},"scene_list":[`), &obj)
+	require.Nil(t, err)
+	require.Equal(t, testObject{Options: []string{"接受"}, Question: "如何面对？"}, obj)
+	require.Equal(t, map[string]any{
+		"question":   "如何面对？",
+		"options":    []any{"接受"},
+		"extra":      map[string]any{"trace_id": "x1"},
+		"scene_list": nil,
+	}, m)
+
+	var options []string
+	m, err = parser.UnmarshalMap([]byte(`["接受","拒绝"]`), &options)
+	require.Nil(t, err)
+	require.Nil(t, m)
+	require.Equal(t, []string{"接受", "拒绝"}, options)
+
+	_, err = parser.UnmarshalMap([]byte(`{"question":1}`), &obj)
+	require.NotNil(t, err)
+}
+
+func TestUnmarshalT(t *testing.T) {
+	obj, err := UnmarshalT[testObject]([]byte(`{"question":"如何面对？","options":["接受","拒`))
+	require.Nil(t, err)
+	require.Equal(t, testObject{Options: []string{"接受"}, Question: "如何面对？"}, obj)
+
+	ids, err := UnmarshalT[[]int]([]byte(`[0x1F,7,`), WithLenient())
+	require.Nil(t, err)
+	require.Equal(t, []int{31, 7}, ids)
+
+	obj, err = UnmarshalT[testObject]([]byte(`not json`))
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+	require.Equal(t, testObject{}, obj)
+}
+
 func TestFastUnmarshal(t *testing.T) {
 	parser := NewJSONParser(true, WithOnExtraToken(func(text string, data any, remaining string) {
 		fmt.Printf("Parsed JSON with extra tokens: text: %s, data: %v, reminding: %s\n", text, data, remaining)
@@ -513,3 +701,283 @@ func BenchmarkFastEnsureJson(b *testing.B) {
 		}
 	}
 }
+
+func TestLenientEscapes(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		opts            []ParserOption
+		err             bool
+	}{
+		{
+			input: `{"a":"\x41"}`,
+			err:   true,
+		},
+		{
+			input:    `{"a":"\x41\q","b":"C:\path\dir"}`,
+			expected: `{"a":"A\\q","b":"C:\\path\\dir"}`,
+			opts:     []ParserOption{WithLenientEscapes()},
+		},
+		{
+			input:    `{"a":"\xe4\xbd\xa0\xe5\xa5\xbd","b":"caf\xe9"`,
+			expected: `{"a":"你好","b":"café"}`,
+			opts:     []ParserOption{WithLenient()},
+		},
+		{
+			input:    `["line1` + "\n" + `line2\u4f60\ud83d\ude00", "\u12"]`,
+			expected: `["line1\nline2你😀","\\u12"]`,
+			opts:     []ParserOption{WithLenient()},
+		},
+	}
+
+	for _, test := range tests {
+		parser := NewJSONParser(true, test.opts...)
+		data, err := parser.EnsureJSON(test.input)
+		if test.err {
+			require.NotNil(t, err)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	type report struct {
+		bytesConsumed, valuesParsed, depth int
+	}
+
+	var reports []report
+	parser := NewJSONParser(true, WithProgress(func(bytesConsumed, valuesParsed int, depth int) {
+		reports = append(reports, report{bytesConsumed, valuesParsed, depth})
+	}))
+
+	input := "[" + strings.Repeat(`[1, 2, 3],`, 600) + `{"a": [true`
+	_, err := parser.EnsureJSON(input)
+	require.Nil(t, err)
+
+	require.Equal(t, []report{
+		{bytesConsumed: 2560, valuesParsed: 1024, depth: 1},
+		{bytesConsumed: 5120, valuesParsed: 2048, depth: 1},
+		{bytesConsumed: len(input), valuesParsed: 2404, depth: 0},
+	}, reports)
+}
+
+func TestLenientProblems(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		offsets         []int
+	}{
+		{
+			input:    `{"a":1,"b":oops,"c":[1,x,3],"d":"ok"`,
+			expected: `{"a":1,"c":[1,3],"d":"ok"}`,
+			offsets:  []int{11, 23},
+		},
+		{
+			input:    `{"a" 1,"b":{"x":[1,2]},"c":2}`,
+			expected: `{"b":{"x":[1,2]},"c":2}`,
+			offsets:  []int{5},
+		},
+		{
+			input:    `[{"a":"x,]"},{"a":"y"`,
+			expected: `[{"a":"x,]"},{"a":"y"}]`,
+		},
+	}
+
+	for _, test := range tests {
+		parser := NewJSONParser(false, WithLenient())
+		data, err := parser.EnsureJSON(test.input)
+		require.Equal(t, test.expected, data)
+
+		var offsets []int
+		if err != nil {
+			for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+				var perr *ParseError
+				require.True(t, errors.As(err, &perr))
+				require.ErrorIs(t, perr, ErrUnexpectedToken)
+				offsets = append(offsets, perr.Offset)
+			}
+		}
+		require.Equal(t, test.offsets, offsets)
+	}
+
+	var v map[string]any
+	err := NewJSONParser(true, WithLenient()).Unmarshal([]byte(`{"a":[1,?],"b":2}`), &v)
+	require.Equal(t, "unexpected token at offset 8 (line 1, column 9)", err.Error())
+	require.Equal(t, map[string]any{"a": []any{float64(1)}, "b": float64(2)}, v)
+
+	_, err = NewJSONParser(true).EnsureJSON(`{"a":1,"b":oops}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+func TestEnsureJSONTo(t *testing.T) {
+	inputs := []string{
+		`{"a": [1, {"b": "x`,
+		`[{"a": 1}, {}`,
+		`{"list": [{"a": 1},{`,
+		`{"list": [{`,
+		`[[{}]]`,
+		`{"a": 1}`,
+		`{"a": 1}}`,
+		`{"a": tr`,
+	}
+
+	parser := NewJSONParser(false)
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			want, wantErr := parser.EnsureJSON(input)
+			var b strings.Builder
+			err := parser.EnsureJSONTo(&b, input)
+			require.Equal(t, wantErr, err)
+			require.Equal(t, want, b.String())
+
+			want, wantErr = parser.FastEnsureJSON(input)
+			b.Reset()
+			err = parser.FastEnsureJSONTo(&b, input)
+			require.Equal(t, wantErr, err)
+			require.Equal(t, want, b.String())
+		})
+	}
+
+	require.ErrorIs(t, parser.EnsureJSONTo(failingWriter{}, `{"a": 1`), io.ErrClosedPipe)
+	require.ErrorIs(t, parser.FastEnsureJSONTo(failingWriter{}, `{"a": [1`), io.ErrClosedPipe)
+}
+
+func TestFastEnsureJSONEmptyObjectTail(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: `{"list": [{"a": 1},{`, expected: `{"list": [{"a": 1}]}`},
+		{input: `{"list": [{"a": 1}, {`, expected: `{"list": [{"a": 1}]}`},
+		{input: `{"x": 1, "list": [{`, expected: `{"x": 1, "list": null}`},
+		{input: `{"x": 1, "list": [ {`, expected: `{"x": 1, "list": null}`},
+		{input: `{"list": [{"a": [1, {}]}`, expected: `{"list": [{"a":[1]}]}`},
+		{input: `{"a": [1, {}]}`, expected: `{"a": [1, {}]}`},
+		{input: `{"a": "[{}]"}`, expected: `{"a": "[{}]"}`},
+		{input: `{"a": [1, "x,{}"]}`, expected: `{"a": [1, "x,{}"]}`},
+		{input: `{"a": [1, {}], "b": 2}`, expected: `{"a": [1, {}], "b": 2}`},
+	}
+
+	parser := NewJSONParser(false)
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			data, err := parser.FastEnsureJSON(test.input)
+			require.Nil(t, err)
+			require.Equal(t, test.expected, data)
+
+			if json.Valid([]byte(test.input)) {
+				// complete documents are copied as is
+				return
+			}
+			full, err := parser.EnsureJSON(test.input)
+			require.Nil(t, err)
+			require.JSONEq(t, full, data)
+		})
+	}
+}
+
+func TestPackageLevelFunctions(t *testing.T) {
+	got, err := EnsureJSON(`{"a": [1, "b`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":[1]}`, got)
+
+	got, err = EnsureJSON(`{'a': 1`, WithAllowSingleQuotes())
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1}`, got)
+
+	var v struct{ A []int }
+	require.Nil(t, Unmarshal([]byte(`{"A": [1, 2`), &v))
+	require.Equal(t, []int{1, 2}, v.A)
+}
+
+func TestJSONParserConcurrentUse(t *testing.T) {
+	var repairs atomic.Int64
+	parser := NewJSONParser(false, WithJSON5(), WithOnRepair(func(Repair) { repairs.Add(1) }))
+	inputs := []string{`{"a": [1, {"b": "x`, `[1, 2, 3`, `{"c": true /* yes */}`, "{\"d\": // note\n 'e'}"}
+
+	want := make([]string, len(inputs))
+	for i, input := range inputs {
+		want[i], _ = parser.EnsureJSON(input)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if got, _ := parser.EnsureJSON(inputs[i%len(inputs)]); got != want[i%len(inputs)] {
+					t.Errorf("EnsureJSON(%q) = %q, want %q", inputs[i%len(inputs)], got, want[i%len(inputs)])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	require.Positive(t, repairs.Load())
+}
+
+func TestTruncatedEscapes(t *testing.T) {
+	tests := []struct {
+		input, expected string
+	}{
+		{input: `{"a":"x\u00`, expected: `{"a":"x"}`},
+		{input: `{"a":"x\`, expected: `{"a":"x"}`},
+		{input: `{"a":"x\ud83d`, expected: `{"a":"x"}`},
+		{input: `{"a":"x\ud83d\`, expected: `{"a":"x"}`},
+		{input: `{"a":"x\ud83d\ud`, expected: `{"a":"x"}`},
+		{input: `{"a":"x😀`, expected: `{"a":"x😀"}`},
+		{input: `{"a":"x\ude00`, expected: `{"a":"x�"}`},
+		{input: `{"a":"x\\ud83d`, expected: `{"a":"x\\ud83d"}`},
+		{input: `{"a":"x\\","b":{"c":"y`, expected: `{"a":"x\\","b":{"c":"y"}}`},
+		{input: `{"a":["x\\"],"b":[{"c":1`, expected: `{"a":["x\\"],"b":[{"c":1}]}`},
+	}
+
+	parser := NewJSONParser(true, WithIncompleteStringMode(IncompleteStringKeep))
+	for _, test := range tests {
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+
+		data, err = parser.FastEnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+	}
+
+	tokens := NewTokenizer(`["x\ud83d\ud`)
+	_, err := tokens.Next()
+	require.Nil(t, err)
+	tok, err := tokens.Next()
+	require.Nil(t, err)
+	require.Equal(t, Token{Kind: TokenStringPartial, Value: "x", Offset: 1}, tok)
+}
+
+func TestFastEnsureJSONEscapedBackslashes(t *testing.T) {
+	tests := []struct {
+		input, expected string
+	}{
+		{input: `{"a":"C:\\dir\\","b":[1,{"c":"d`, expected: `{"a":"C:\\dir\\","b":[1,{"c":"d"}]}`},
+		{input: `{"a\\":1,"b":[1`, expected: `{"a\\":1,"b":[1]}`},
+		{input: `{"a":"\\\\","b":{"c":[`, expected: `{"a":"\\\\","b":{"c":null}}`},
+		{input: `{"a":"\\\"}","b":[{"c":1`, expected: `{"a":"\\\"}","b":[{"c":1}]}`},
+		{input: `{"a\\":"x`, expected: `{"a\\":"x"}`},
+		{input: `["\\\\\\"]",{"a":"\\`, expected: `["\\\\\\"]`},
+	}
+
+	parser := NewJSONParser(false)
+	for _, test := range tests {
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+
+		data, err = parser.FastEnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+	}
+}
@@ -0,0 +1,96 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LineError describes a line of JSON Lines input that could not be repaired
+type LineError struct {
+	// Line is the 1-based number of the line
+	Line int
+	// Err is the error returned by the repair
+	Err error
+	// Raw is the content of the line, without its line terminator
+	Raw string
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// JSONLReader reads JSON Lines input, repairing each line. Blank lines are ignored
+// and the lines that can not be repaired are skipped and reported, while the lines
+// repaired with problems skipped over in lenient mode are returned with them
+type JSONLReader struct {
+	parser *JSONParser
+	r      *bufio.Reader
+	line   int
+	onSkip func(*LineError)
+}
+
+// NewJSONLReader creates a JSONLReader reading r, calling onSkip, if not nil, for every skipped line
+func NewJSONLReader(p *JSONParser, r io.Reader, onSkip func(*LineError)) *JSONLReader {
+	return &JSONLReader{
+		parser: p,
+		r:      bufio.NewReader(r),
+		onSkip: onSkip,
+	}
+}
+
+// Read returns the repaired JSON of the next line that can be repaired, and io.EOF at the end
+// of the input. A line repaired with problems is returned alongside a *LineError holding them.
+// Other errors are the errors of the underlying reader
+func (r *JSONLReader) Read() (string, error) {
+	for {
+		raw, err := r.r.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		if raw == "" && err == io.EOF {
+			return "", io.EOF
+		}
+		r.line++
+
+		raw = strings.TrimRight(raw, "\r\n")
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		sp := r.parser.session(strings.TrimSpace(raw))
+		value, perr := sp.run()
+		if perr != nil {
+			// the line is skipped rather than returned parsed up to the failure point
+			value = nil
+		}
+		data, perr := sp.marshal(value, perr)
+		if data == "" {
+			if r.onSkip != nil {
+				r.onSkip(&LineError{Line: r.line, Err: perr, Raw: raw})
+			}
+			continue
+		}
+		if perr != nil {
+			return data, &LineError{Line: r.line, Err: perr, Raw: raw}
+		}
+
+		return data, nil
+	}
+}
+
+// Line returns the number of the line last read
+func (r *JSONLReader) Line() int {
+	return r.line
+}
@@ -0,0 +1,58 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestJSONLReader(t *testing.T) {
+	input := "{\"a\":1}\r\n\n{\"a\":2\nnot json\n{\"a\":[3,\n[1,tru\n{\"a\":4}"
+
+	var skipped []*LineError
+	r := NewJSONLReader(NewJSONParser(true), strings.NewReader(input), func(e *LineError) {
+		skipped = append(skipped, e)
+	})
+
+	var lines []string
+	for {
+		line, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		require.Nil(t, err)
+		lines = append(lines, line)
+	}
+
+	require.Equal(t, []string{`{"a":1}`, `{"a":2}`, `{"a":[3]}`, `{"a":4}`}, lines)
+	require.Equal(t, []*LineError{
+		{Line: 4, Err: &ParseError{Offset: 0, Line: 1, Column: 1, Token: "not", Err: ErrUnexpectedToken}, Raw: "not json"},
+		{Line: 6, Err: &ParseError{Offset: 3, Line: 1, Column: 4, Token: "tru", Err: ErrUnexpectedToken}, Raw: "[1,tru"},
+	}, skipped)
+	require.Equal(t, "line 4: unexpected token at offset 0 (line 1, column 1)", skipped[0].Error())
+	require.Equal(t, 7, r.Line())
+
+	skipped = nil
+	r = NewJSONLReader(NewJSONParser(true, WithLenient()), strings.NewReader("{\"a\":1,\"x\":bad,\"b\":2}\nnot json\n{\"a\":3}"), func(e *LineError) {
+		skipped = append(skipped, e)
+	})
+	line, err := r.Read()
+	require.Equal(t, `{"a":1,"b":2}`, line)
+	var lerr *LineError
+	require.ErrorAs(t, err, &lerr)
+	require.Equal(t, 1, lerr.Line)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+
+	line, err = r.Read()
+	require.Nil(t, err)
+	require.Equal(t, `{"a":3}`, line)
+	require.Len(t, skipped, 1)
+	require.Equal(t, 2, skipped[0].Line)
+}
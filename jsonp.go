@@ -0,0 +1,54 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"strings"
+	"unicode"
+)
+
+// jsonpPrefix returns the length of the callback( wrapper opening a JSONP payload,
+// optionally preceded by the /**/ guard some servers emit, 0 if s is not JSONP
+func jsonpPrefix(s string) int {
+	rest := strings.TrimLeftFunc(s, unicode.IsSpace)
+	rest = strings.TrimPrefix(rest, "/**/")
+	rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+
+	i := 0
+	for i < len(rest) && (rest[i] == '_' || rest[i] == '$' || rest[i] == '.' ||
+		'a' <= rest[i] && rest[i] <= 'z' || 'A' <= rest[i] && rest[i] <= 'Z' || i > 0 && '0' <= rest[i] && rest[i] <= '9') {
+		i++
+	}
+	if i == 0 || rest[0] == '.' {
+		return 0
+	}
+
+	rest = strings.TrimLeftFunc(rest[i:], unicode.IsSpace)
+	if !strings.HasPrefix(rest, "(") {
+		return 0
+	}
+	rest = strings.TrimLeftFunc(rest[1:], unicode.IsSpace)
+
+	return len(s) - len(rest)
+}
+
+// stripJSONPSuffix returns the remaining text s without the ); closing a JSONP wrapper
+func (p *JSONParser) stripJSONPSuffix(s string) string {
+	rest := strings.TrimLeftFunc(s, unicode.IsSpace)
+	if !strings.HasPrefix(rest, ")") {
+		return s
+	}
+
+	end := strings.TrimLeftFunc(rest[1:], unicode.IsSpace)
+	end = strings.TrimLeftFunc(strings.TrimPrefix(end, ";"), unicode.IsSpace)
+	if end != "" {
+		return s
+	}
+
+	p.repair(RepairStrippedJSONP, rest, strings.TrimRightFunc(rest, unicode.IsSpace), "")
+	return ""
+}
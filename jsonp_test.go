@@ -0,0 +1,70 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestJSONP(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		repairs         []Repair
+		err             error
+	}{
+		{
+			input:    `callback({"a":[1,2]});`,
+			expected: `{"a":[1,2]}`,
+			repairs: []Repair{
+				{Offset: 0, Original: "callback(", Replacement: "", Kind: RepairStrippedJSONP},
+				{Offset: 20, Original: ");", Replacement: "", Kind: RepairStrippedJSONP},
+			},
+		},
+		{
+			input:    "/**/ jQuery_123.cb ( [\"x\",{\"b\":\"tr",
+			expected: `["x",{"b":"tr"}]`,
+			repairs: []Repair{
+				{Offset: 0, Original: "/**/ jQuery_123.cb ( ", Replacement: "", Kind: RepairStrippedJSONP},
+				{Offset: 34, Original: "", Replacement: `"`, Kind: RepairClosedString},
+				{Offset: 34, Original: "", Replacement: "}", Kind: RepairClosedBracket},
+				{Offset: 34, Original: "", Replacement: "]", Kind: RepairClosedBracket},
+			},
+		},
+		{
+			input:    `cb({"a":1}) + 1`,
+			expected: `{"a":1}`,
+			repairs: []Repair{
+				{Offset: 0, Original: "cb(", Replacement: "", Kind: RepairStrippedJSONP},
+			},
+		},
+		{
+			input: `1cb({"a":1})`,
+			err:   ErrUnexpectedToken,
+		},
+	}
+
+	for _, test := range tests {
+		var repairs []Repair
+		parser := NewJSONParser(false, WithOnRepair(func(r Repair) {
+			repairs = append(repairs, r)
+		}))
+
+		data, err := parser.EnsureJSON(test.input)
+		if test.err == nil {
+			require.Nil(t, err)
+		} else {
+			require.ErrorIs(t, err, test.err)
+		}
+		require.Equal(t, test.expected, data)
+		require.Equal(t, test.repairs, repairs)
+	}
+
+	data, err := NewJSONParser(true).FastEnsureJSON(`cb({"a":1});`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1}`, data)
+}
@@ -0,0 +1,86 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json/jsontext"
+	"errors"
+	"io"
+)
+
+// EncodeTokens repairs s and writes the repaired document to enc as a single value, so that it
+// can be composed into a stream written with the json/v2 token layer. The document is encoded as
+// EnsureJSON encodes it. Errors are returned as EnsureJSON does
+func (p *JSONParser) EncodeTokens(enc *jsontext.Encoder, s string) error {
+	sp := p.session(s)
+	data, err := sp.run()
+	if err != nil && p.onIrreparable != nil {
+		p.onIrreparable(sp.classify(err))
+	}
+	if err != nil && data == nil {
+		return err
+	}
+
+	b, merr := sp.encode(data)
+	if merr != nil {
+		return merr
+	}
+	if werr := enc.WriteValue(jsontext.Value(b)); werr != nil {
+		return werr
+	}
+	if err != nil {
+		return err
+	}
+
+	return sp.problems()
+}
+
+// CompleteTokens copies the tokens read from dec to enc until the end of the input. When the
+// input ends inside a value, the token cut by the end is dropped, a member missing its value
+// gets null and the open objects and arrays are closed, so that enc receives a complete document
+func CompleteTokens(enc *jsontext.Encoder, dec *jsontext.Decoder) error {
+	for {
+		tok, err := dec.ReadToken()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if !errors.Is(err, io.ErrUnexpectedEOF) || dec.StackDepth() == 0 {
+				return err
+			}
+			return closeTokens(enc, dec)
+		}
+
+		if err := enc.WriteToken(tok); err != nil {
+			return err
+		}
+	}
+}
+
+// closeTokens writes the tokens completing the values left open by dec to enc
+func closeTokens(enc *jsontext.Encoder, dec *jsontext.Decoder) error {
+	for depth := dec.StackDepth(); depth > 0; depth-- {
+		kind, length := dec.StackIndex(depth)
+		end := jsontext.EndArray
+		if kind == jsontext.KindBeginObject {
+			end = jsontext.EndObject
+			if length%2 == 1 {
+				if err := enc.WriteToken(jsontext.Null); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := enc.WriteToken(end); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
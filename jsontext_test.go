@@ -0,0 +1,54 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestEncodeTokens(t *testing.T) {
+	var buf bytes.Buffer
+	enc := jsontext.NewEncoder(&buf)
+
+	require.Nil(t, enc.WriteToken(jsontext.BeginArray))
+	require.Nil(t, NewJSONParser(false).EncodeTokens(enc, `{"question":"如何","options":["接受",1e2,true,null`))
+	require.Nil(t, NewJSONParser(true, WithBigNumbers(BigNumberBigInt)).EncodeTokens(enc, `{"id":12345678901234567890}`))
+	require.Nil(t, NewJSONParser(true, WithKeyOrder()).EncodeTokens(enc, `{"b":1,"a":2}`))
+	require.Nil(t, enc.WriteToken(jsontext.EndArray))
+	require.Equal(t, `[{"options":["接受",100,true,null],"question":"如何"},{"id":12345678901234567890},{"b":1,"a":2}]`+"\n", buf.String())
+
+	require.ErrorIs(t, NewJSONParser(true).EncodeTokens(enc, `not json`), ErrUnexpectedToken)
+}
+
+func TestCompleteTokens(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: `{"a":[1,{"b":"x"},2`, expected: `{"a":[1,{"b":"x"},2]}`},
+		{input: `{"a":{"b":`, expected: `{"a":{"b":null}}`},
+		{input: `{"a":{"b":"xy`, expected: `{"a":{"b":null}}`},
+		{input: `[true,fa`, expected: `[true]`},
+		{input: `{"a":1}`, expected: `{"a":1}`},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		err := CompleteTokens(jsontext.NewEncoder(&buf), jsontext.NewDecoder(strings.NewReader(test.input)))
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected+"\n", buf.String(), test.input)
+	}
+
+	err := CompleteTokens(jsontext.NewEncoder(&bytes.Buffer{}), jsontext.NewDecoder(strings.NewReader(`{"a":]`)))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,135 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+)
+
+// WithKeyOrder keeps the members of objects in the order they appear in the input in the
+// repaired JSON text, instead of sorting them by name, so that successive partial states of a
+// document can be diffed and cached as prefixes of each other. Members added by transforms are
+// written after the ones of the input, sorted by name
+func WithKeyOrder() ParserOption {
+	return func(p *JSONParser) {
+		p.keyOrder = true
+	}
+}
+
+// keyOrder holds the member names of an object in the order they appear in the input
+type keyOrder struct {
+	keys []string
+	seen map[string]bool
+}
+
+// noteKey records the key of a member of the object being parsed
+func (p *JSONParser) noteKey(key string) {
+	if !p.keyOrder {
+		return
+	}
+
+	st := p.state
+	if st.keyOrders == nil {
+		st.keyOrders = make(map[string]*keyOrder)
+	}
+	path := formatPath(st.path)
+	order := st.keyOrders[path]
+	if order == nil {
+		order = &keyOrder{seen: make(map[string]bool)}
+		st.keyOrders[path] = order
+	}
+	if !order.seen[key] {
+		order.seen[key] = true
+		order.keys = append(order.keys, key)
+	}
+}
+
+// encode returns the JSON encoding of the value data parsed by the session
+func (p *JSONParser) encode(data any) ([]byte, error) {
+	if !p.keyOrder {
+		return json.Marshal(data)
+	}
+
+	var buf bytes.Buffer
+	if err := p.encodeOrdered(&buf, data, nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeTo writes the JSON encoding of the value data parsed by the session to buf, as encode returns it
+func (p *JSONParser) encodeTo(buf *bytes.Buffer, data any) error {
+	if p.keyOrder {
+		return p.encodeOrdered(buf, data, nil)
+	}
+
+	if err := json.NewEncoder(buf).Encode(data); err != nil {
+		return err
+	}
+	// Encode ends the encoding with a newline json.Marshal does not write
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
+
+// encodeOrdered writes the JSON encoding of the value v found at path to buf, the members of
+// objects in the order recorded by noteKey
+func (p *JSONParser) encodeOrdered(buf *bytes.Buffer, v any, path []any) error {
+	switch val := v.(type) {
+	case map[string]any:
+		var keys []string
+		order := p.state.keyOrders[formatPath(path)]
+		if order != nil {
+			for _, key := range order.keys {
+				if _, ok := val[key]; ok {
+					keys = append(keys, key)
+				}
+			}
+		}
+		var added []string
+		for key := range val {
+			if order == nil || !order.seen[key] {
+				added = append(added, key)
+			}
+		}
+		slices.Sort(added)
+
+		buf.WriteByte('{')
+		for i, key := range append(keys, added...) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			b, _ := json.Marshal(key)
+			buf.Write(b)
+			buf.WriteByte(':')
+			if err := p.encodeOrdered(buf, val[key], append(path, key)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, elem := range val {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := p.encodeOrdered(buf, elem, append(path, i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+	}
+
+	return nil
+}
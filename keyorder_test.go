@@ -0,0 +1,57 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestKeyOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		opts     []ParserOption
+	}{
+		{
+			input:    `{"question":"如何","options":["接受"],"b":{"z":1,"a":2},"a":[{"y":1,"x":"<`,
+			expected: `{"question":"如何","options":["接受"],"b":{"z":1,"a":2},"a":[{"y":1,"x":"\u003c"}]}`,
+		},
+		{
+			input:    `{"z":1,"a":2,"z":3}`,
+			expected: `{"z":3,"a":2}`,
+		},
+		{
+			input:    `{"z":1,"a":{"k":true}}`,
+			expected: `{"z":1,"a":{"k":true},"added":1,"m":2}`,
+			opts: []ParserOption{WithTransforms(func(doc *Document) error {
+				if err := doc.Set("m", 2); err != nil {
+					return err
+				}
+				return doc.Set("added", 1)
+			})},
+		},
+	}
+
+	for _, test := range tests {
+		data, err := NewJSONParser(false, append(test.opts, WithKeyOrder())...).EnsureJSON(test.input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+	}
+
+	dec := NewStreamDecoder(NewJSONParser(true, WithKeyOrder()))
+	snapshot, err := dec.Feed([]byte(`{"question":"如何","done":`))
+	require.Nil(t, err)
+	require.Equal(t, `{"question":"如何","done":null}`, snapshot.JSON)
+	snapshot, err = dec.Feed([]byte(`true}`))
+	require.Nil(t, err)
+	require.Equal(t, `{"question":"如何","done":true}`, snapshot.JSON)
+
+	data, err := NewJSONParser(true).EnsureJSON(`{"z":1,"a":2}`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":2,"z":1}`, data)
+}
@@ -0,0 +1,114 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import "errors"
+
+// ErrDanglingKey is returned when the input ends after a key with DanglingKeyFail
+var ErrDanglingKey = errors.New("key without value")
+
+// DanglingKeyPolicy controls how a key the input ends after, as in {"question" or {"question":, is handled
+type DanglingKeyPolicy int
+
+const (
+	// DanglingKeyNull emits the key with a null value. It is the default behavior
+	DanglingKeyNull DanglingKeyPolicy = iota
+	// DanglingKeyDrop leaves the key out until its value starts
+	DanglingKeyDrop
+	// DanglingKeyFail fails with a *ParseError wrapping ErrDanglingKey at the offset of the key
+	DanglingKeyFail
+)
+
+// WithDanglingKeys sets how a key the input ends after is handled
+func WithDanglingKeys(policy DanglingKeyPolicy) ParserOption {
+	return func(p *JSONParser) {
+		p.danglingKeys = policy
+		p.danglingKeysSet = true
+	}
+}
+
+// danglingKeyPolicy returns how a key the input ends after is handled
+func (p *JSONParser) danglingKeyPolicy() DanglingKeyPolicy {
+	if p.danglingKeysSet {
+		return p.danglingKeys
+	}
+
+	return p.compat.danglingKeys
+}
+
+// WithIncompleteKeyPrefix emits in non-strict mode a key cut by the end of the input, instead of
+// dropping it, as a member named after the streamed prefix of the key with a null value,
+// so that UIs can show that a new field is arriving
+func WithIncompleteKeyPrefix() ParserOption {
+	return func(p *JSONParser) {
+		p.incompleteKey = func(prefix string) string { return prefix }
+	}
+}
+
+// WithIncompleteKeyPlaceholder emits in non-strict mode a key cut by the end of the input, instead
+// of dropping it, as a member named placeholder with a null value
+func WithIncompleteKeyPlaceholder(placeholder string) ParserOption {
+	return func(p *JSONParser) {
+		p.incompleteKey = func(string) string { return placeholder }
+	}
+}
+
+// emitIncompleteKey adds the member standing for the key cut by the end of the input s to acc,
+// reporting false when incomplete keys are dropped or s does not start a quoted key
+func (p *JSONParser) emitIncompleteKey(s string, acc map[string]any) bool {
+	if p.incompleteKey == nil || len(s) == 0 || (s[0] != '"' && !(p.singleQuotes && s[0] == '\'')) {
+		return false
+	}
+
+	raw := s[1:]
+	if p.garbage == GarbageSkip {
+		raw = dropGarbage(raw)
+	}
+	if s[0] == '\'' {
+		raw = unescapeSingleQuote(raw)
+	}
+	key := p.incompleteKey(partialString(raw))
+	if key == "" || p.checkKeys(s, key, acc) != nil {
+		return false
+	}
+
+	p.noteKey(key)
+	acc[key] = nil
+	p.pushPath(key)
+	p.truncate(s, KindUnknown, nil)
+	p.popPath()
+
+	return true
+}
+
+// danglingKey handles the member key of acc found at start, whose value is missing as the
+// remaining text s is empty or closes the object
+func (p *JSONParser) danglingKey(start, s, key string, acc map[string]any) error {
+	if len(s) > 0 {
+		acc[key] = nil
+		return nil
+	}
+
+	switch p.danglingKeyPolicy() {
+	case DanglingKeyDrop:
+		p.repair(RepairDroppedTrailingMember, start, start, "")
+		p.truncate(start, KindObject, nil)
+	case DanglingKeyFail:
+		return &ParseError{Offset: p.offset(start), Err: ErrDanglingKey}
+	default:
+		acc[key] = nil
+		p.repair(RepairDroppedTrailingMember, s, "", "null")
+		p.truncateMember(s, key)
+		p.pushPath(key)
+		if v, ok := p.placeholder(); ok {
+			acc[key] = v
+		}
+		p.popPath()
+	}
+
+	return nil
+}
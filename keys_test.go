@@ -0,0 +1,91 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIncompleteKeys(t *testing.T) {
+	tests := []struct {
+		opt      ParserOption
+		input    string
+		expected string
+	}{
+		{
+			opt:      WithIncompleteKeyPrefix(),
+			input:    `{"question":"如何面对？","opt`,
+			expected: `{"opt":null,"question":"如何面对？"}`,
+		},
+		{
+			opt:      WithIncompleteKeyPrefix(),
+			input:    `{"scene":{"name":"夜晚","role_\u540`,
+			expected: `{"scene":{"name":"夜晚","role_":null}}`,
+		},
+		{
+			opt:      WithIncompleteKeyPrefix(),
+			input:    `{"question":"如何面对？","`,
+			expected: `{"question":"如何面对？"}`,
+		},
+		{
+			opt:      WithIncompleteKeyPlaceholder("…"),
+			input:    `{"question":"如何面对？","`,
+			expected: `{"question":"如何面对？","…":null}`,
+		},
+	}
+
+	for _, test := range tests {
+		s, err := NewJSONParser(false, test.opt).EnsureJSON(test.input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, s, test.input)
+	}
+
+	s, err := NewJSONParser(true, WithIncompleteKeyPrefix()).EnsureJSON(`{"a":1,"opt`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1}`, s)
+
+	dec := NewStreamDecoder(NewJSONParser(false, WithIncompleteKeyPrefix()))
+	snapshot, err := dec.Feed([]byte(`{"a":1,"opt`))
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1,"opt":null}`, snapshot.JSON)
+	require.Equal(t, 7, snapshot.Committed)
+}
+
+func TestDanglingKeys(t *testing.T) {
+	tests := []struct {
+		policy   DanglingKeyPolicy
+		strict   bool
+		input    string
+		expected string
+		err      error
+	}{
+		{policy: DanglingKeyNull, strict: true, input: `{"a":1,"question"`, expected: `{"a":1,"question":null}`},
+		{policy: DanglingKeyNull, input: `{"a":1,"question": `, expected: `{"a":1,"question":null}`},
+		{policy: DanglingKeyDrop, strict: true, input: `{"a":1,"question"`, expected: `{"a":1}`},
+		{policy: DanglingKeyDrop, input: `{"a":{"question":`, expected: `{"a":{}}`},
+		{policy: DanglingKeyDrop, input: `{"a":1,"question":}`, expected: `{"a":1,"question":null}`},
+		{
+			policy:   DanglingKeyFail,
+			input:    `{"a":1,"question":`,
+			expected: `{"a":1}`,
+			err:      &ParseError{Offset: 7, Line: 1, Column: 8, Token: `"question"`, Err: ErrDanglingKey},
+		},
+	}
+
+	for _, test := range tests {
+		s, err := NewJSONParser(test.strict, WithDanglingKeys(test.policy)).EnsureJSON(test.input)
+		require.Equal(t, test.err, err, test.input)
+		require.Equal(t, test.expected, s, test.input)
+	}
+
+	dec := NewStreamDecoder(NewJSONParser(true, WithDanglingKeys(DanglingKeyDrop)))
+	snapshot, err := dec.Feed([]byte(`{"a":1,"question":`))
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1}`, snapshot.JSON)
+	require.Equal(t, 7, snapshot.Committed)
+}
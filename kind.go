@@ -0,0 +1,71 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+// Kind is the kind of a JSON value
+type Kind int
+
+const (
+	// KindUnknown is the kind of a value that has not started yet
+	KindUnknown Kind = iota
+	// KindNull is the kind of null
+	KindNull
+	// KindBool is the kind of true and false
+	KindBool
+	// KindNumber is the kind of numbers
+	KindNumber
+	// KindString is the kind of strings
+	KindString
+	// KindArray is the kind of arrays
+	KindArray
+	// KindObject is the kind of objects
+	KindObject
+)
+
+var kindNames = [...]string{
+	KindUnknown: "unknown",
+	KindNull:    "null",
+	KindBool:    "bool",
+	KindNumber:  "number",
+	KindString:  "string",
+	KindArray:   "array",
+	KindObject:  "object",
+}
+
+// String returns the name of the kind
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "unknown"
+	}
+
+	return kindNames[k]
+}
+
+// kindOf returns the kind of a parsed value
+func kindOf(v any) Kind {
+	switch v.(type) {
+	case nil:
+		return KindNull
+	case bool:
+		return KindBool
+	case float64, json.Number, *big.Int:
+		return KindNumber
+	case string:
+		return KindString
+	case []any:
+		return KindArray
+	case map[string]any:
+		return KindObject
+	}
+
+	return KindUnknown
+}
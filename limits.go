@@ -0,0 +1,116 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"fmt"
+)
+
+// KeyLimitError is returned when an object has more members than allowed by WithMaxKeysPerObject
+type KeyLimitError struct {
+	// Path is the path of the object in the dotted syntax
+	Path string
+	// Limit is the maximum number of members
+	Limit int
+	// Offset is the byte offset of the first member over the limit
+	Offset int
+}
+
+func (e *KeyLimitError) Error() string {
+	return fmt.Sprintf("object %q has more than %d members at offset %d", e.Path, e.Limit, e.Offset)
+}
+
+// WithMaxKeysPerObject fails with a *KeyLimitError on objects having more than n members, protecting
+// against degenerate outputs emitting thousands of junk keys into a single object
+func WithMaxKeysPerObject(n int) ParserOption {
+	return func(p *JSONParser) {
+		p.maxKeys = n
+	}
+}
+
+// checkKeys returns the error of adding the member key found at s to acc, nil if within the limit
+func (p *JSONParser) checkKeys(s, key string, acc map[string]any) error {
+	if p.maxKeys <= 0 || len(acc) < p.maxKeys {
+		return nil
+	}
+	if _, ok := acc[key]; ok {
+		return nil
+	}
+
+	return &KeyLimitError{Path: formatPath(p.state.path), Limit: p.maxKeys, Offset: p.offset(s)}
+}
+
+// DepthLimitError is returned when objects and arrays are nested deeper than allowed by WithMaxDepth
+type DepthLimitError struct {
+	// Path is the path of the container over the limit in the dotted syntax
+	Path string
+	// Limit is the maximum depth
+	Limit int
+	// Offset is the byte offset of the container over the limit
+	Offset int
+}
+
+func (e *DepthLimitError) Error() string {
+	return fmt.Sprintf("container %q is nested deeper than %d levels at offset %d", e.Path, e.Limit, e.Offset)
+}
+
+// SizeLimitError is returned when the input is larger than allowed by WithMaxInputBytes
+type SizeLimitError struct {
+	// Limit is the maximum size in bytes
+	Limit int
+	// Size is the size of the input in bytes, or the number of bytes read when a
+	// stream repaired by RepairStream went over the limit
+	Size int
+}
+
+func (e *SizeLimitError) Error() string {
+	return fmt.Sprintf("input of %d bytes is larger than %d bytes", e.Size, e.Limit)
+}
+
+// isLimitError reports whether err is the error of a parser limit, which more input can not fix
+func isLimitError(err error) bool {
+	var keys *KeyLimitError
+	var depth *DepthLimitError
+	var size *SizeLimitError
+	return errors.As(err, &keys) || errors.As(err, &depth) || errors.As(err, &size)
+}
+
+// WithMaxDepth fails with a *DepthLimitError on objects and arrays nested more than n levels deep,
+// the top-level value being at level 1, protecting servers parsing untrusted input from exhausting
+// the stack on inputs such as [[[[[[...
+func WithMaxDepth(n int) ParserOption {
+	return func(p *JSONParser) {
+		p.maxDepth = n
+	}
+}
+
+// WithMaxInputBytes fails with a *SizeLimitError on inputs larger than n bytes before parsing them.
+// A StreamDecoder fails on the chunk making the document larger, keeping the data received before
+func WithMaxInputBytes(n int) ParserOption {
+	return func(p *JSONParser) {
+		p.maxInputBytes = n
+	}
+}
+
+// checkDepth returns the error of entering the container starting at s at the current depth, nil if within the limit
+func (p *JSONParser) checkDepth(s string) error {
+	if p.maxDepth <= 0 || p.state.depth <= p.maxDepth {
+		return nil
+	}
+
+	return &DepthLimitError{Path: formatPath(p.state.path), Limit: p.maxDepth, Offset: p.offset(s)}
+}
+
+// checkSize returns the error of parsing an input of n bytes, nil if within the limit
+func (p *JSONParser) checkSize(n int) error {
+	if p.maxInputBytes <= 0 || n <= p.maxInputBytes {
+		return nil
+	}
+
+	return &SizeLimitError{Limit: p.maxInputBytes, Size: n}
+}
@@ -0,0 +1,77 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestMaxKeysPerObject(t *testing.T) {
+	parser := NewJSONParser(true, WithMaxKeysPerObject(2))
+
+	data, err := parser.EnsureJSON(`{"a":1,"b":{"x":1,"y":2},"a":3}`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":3,"b":{"x":1,"y":2}}`, data)
+
+	data, err = parser.EnsureJSON(`{"a":1,"b":{"x":1,"y":2,"z":3}}`)
+	require.Equal(t, &KeyLimitError{Path: "b", Limit: 2, Offset: 24}, err)
+	require.Equal(t, `{"a":1,"b":{"x":1,"y":2}}`, data)
+
+	_, err = parser.EnsureJSON(`{"a":1,"b":2,"c"`)
+	require.Equal(t, &KeyLimitError{Path: "", Limit: 2, Offset: 13}, err)
+
+	data, err = NewJSONParser(false, WithMaxKeysPerObject(1), WithIncompleteKeyPrefix()).EnsureJSON(`{"a":1,"b`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1}`, data)
+}
+
+func TestMaxDepth(t *testing.T) {
+	parser := NewJSONParser(false, WithMaxDepth(2))
+
+	data, err := parser.EnsureJSON(`{"a":[1,2],"b":{"c":1}}`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":[1,2],"b":{"c":1}}`, data)
+
+	data, err = parser.EnsureJSON(`{"a":[1,[2]],"b":1}`)
+	require.Equal(t, &DepthLimitError{Path: "a.1", Limit: 2, Offset: 8}, err)
+	require.Equal(t, `{"a":[1]}`, data)
+
+	_, err = parser.FastEnsureJSON(`{"a":[1,[2]],"b":1`)
+	require.Equal(t, &DepthLimitError{Path: "a.1", Limit: 2, Offset: 8}, err)
+
+	_, err = parser.FastEnsureJSON(strings.Repeat("[", 100000))
+	require.Equal(t, &DepthLimitError{Path: "0.0", Limit: 2, Offset: 2}, err)
+
+	require.EqualError(t, err, `container "0.0" is nested deeper than 2 levels at offset 2`)
+}
+
+func TestMaxInputBytes(t *testing.T) {
+	parser := NewJSONParser(true, WithMaxInputBytes(8))
+
+	data, err := parser.EnsureJSON(`{"a":1}`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1}`, data)
+
+	_, err = parser.EnsureJSON(`{"a":"123456`)
+	require.Equal(t, &SizeLimitError{Limit: 8, Size: 12}, err)
+	require.EqualError(t, err, "input of 12 bytes is larger than 8 bytes")
+
+	_, err = parser.FastEnsureJSON(`{"a":"123456`)
+	require.Equal(t, &SizeLimitError{Limit: 8, Size: 12}, err)
+
+	dec := NewStreamDecoder(parser)
+	snapshot, err := dec.Feed([]byte(`{"a":`))
+	require.Nil(t, err)
+	require.Equal(t, `{"a":null}`, snapshot.JSON)
+
+	snapshot, err = dec.Feed([]byte(`"1234`))
+	require.Equal(t, &SizeLimitError{Limit: 8, Size: 10}, err)
+	require.Equal(t, `{"a":null}`, snapshot.JSON)
+	require.Equal(t, `{"a":null}`, dec.Snapshot().JSON)
+}
@@ -0,0 +1,102 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidLiteral is returned by every parse of a parser given a literal WithLiterals can not register
+var ErrInvalidLiteral = errors.New("invalid literal")
+
+// errCutLiteral is returned by the parsers of literals cut by the end of the input, with no remaining
+// text, the containers dropping the member or element holding it
+var errCutLiteral = errors.New("cut literal")
+
+// literal is a token registered with WithLiterals and the value it stands for
+type literal struct {
+	token string
+	value any
+	// replacement is the JSON encoding of value
+	replacement string
+}
+
+// WithLiterals registers tokens replaced by a value when found outside strings in lenient mode,
+// such as N/A or <nil> standing for null and yes or no standing for booleans. A token ending with
+// a letter, a digit or an underscore only matches when not followed by another one. A literal
+// cut by the end of the input is recorded as a truncation, its member or element being left out.
+// Values must be encodable by encoding/json, and true, false and null can not be redefined: every
+// parse fails with an error wrapping ErrInvalidLiteral when an entry breaks these rules
+func WithLiterals(literals map[string]any) ParserOption {
+	return func(p *JSONParser) {
+		for token, value := range literals {
+			if token == "" || token == "true" || token == "false" || token == "null" {
+				p.optionErr = errors.Join(p.optionErr, fmt.Errorf("%w: %q can not be redefined", ErrInvalidLiteral, token))
+				continue
+			}
+
+			replacement, err := json.Marshal(value)
+			if err != nil {
+				p.optionErr = errors.Join(p.optionErr, fmt.Errorf("%w: %q: %w", ErrInvalidLiteral, token, err))
+				continue
+			}
+			p.literals = append(p.literals, literal{token: token, value: value, replacement: string(replacement)})
+		}
+
+		// the longest token wins when several match
+		sort.Slice(p.literals, func(i, j int) bool {
+			a, b := p.literals[i].token, p.literals[j].token
+			if len(a) != len(b) {
+				return len(a) > len(b)
+			}
+			return a < b
+		})
+	}
+}
+
+// matchLiteral returns the registered literal s starts with and whether it is complete,
+// it is not when the input ends inside the token
+func (p *JSONParser) matchLiteral(s string) (literal, bool, bool) {
+	for _, lit := range p.literals {
+		if strings.HasPrefix(s, lit.token) {
+			n := len(lit.token)
+			if n < len(s) && identByte(lit.token[n-1]) && identByte(s[n]) {
+				continue
+			}
+			return lit, true, true
+		}
+	}
+
+	for _, lit := range p.literals {
+		if strings.HasPrefix(lit.token, s) {
+			return lit, false, true
+		}
+	}
+
+	return literal{}, false, false
+}
+
+// identByte reports whether c can be part of an identifier
+func identByte(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// parseLiteral parses a literal registered with WithLiterals
+func (p *JSONParser) parseLiteral(s string) (any, string, error) {
+	lit, complete, _ := p.matchLiteral(s)
+	if !complete {
+		p.truncate(s, kindOf(lit.value), nil)
+		return nil, "", errCutLiteral
+	}
+
+	p.repair(RepairReplacedLiteral, s, lit.token, lit.replacement)
+	return lit.value, s[len(lit.token):], nil
+}
@@ -0,0 +1,75 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestLiterals(t *testing.T) {
+	literals := WithLiterals(map[string]any{"N/A": nil, "<nil>": nil, "yes": true, "no": false})
+
+	var repairs []Repair
+	parser := NewJSONParser(true, WithLenient(), literals, WithOnRepair(func(r Repair) {
+		repairs = append(repairs, r)
+	}))
+
+	data, err := parser.EnsureJSON(`{"a":N/A,"b":[yes,no,<nil>],"c":"yes","d":null}`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":null,"b":[true,false,null],"c":"yes","d":null}`, data)
+	require.Equal(t, []Repair{
+		{Offset: 5, Original: "N/A", Replacement: "null", Kind: RepairReplacedLiteral},
+		{Offset: 14, Original: "yes", Replacement: "true", Kind: RepairReplacedLiteral},
+		{Offset: 18, Original: "no", Replacement: "false", Kind: RepairReplacedLiteral},
+		{Offset: 21, Original: "<nil>", Replacement: "null", Kind: RepairReplacedLiteral},
+	}, repairs)
+
+	data, err = parser.EnsureJSON(`{"a":nothing,"b":no}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+	require.Equal(t, `{"b":false}`, data)
+
+	dec := NewStreamDecoder(NewJSONParser(true, WithLenient(), literals))
+	snapshot, err := dec.Feed([]byte(`{"a":1,"b":ye`))
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1}`, snapshot.JSON)
+	require.False(t, snapshot.Complete)
+	snapshot, err = dec.Feed([]byte(`s}`))
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1,"b":true}`, snapshot.JSON)
+
+	tests := []struct {
+		input, expected string
+	}{
+		{input: `[1,<ni`, expected: `[1]`},
+		{input: `{"x":{"a":ye`, expected: `{"x":{}}`},
+		{input: `{"x":[1,{"a":2,"b":N/`, expected: `{"x":[1,{"a":2}]}`},
+	}
+
+	for _, test := range tests {
+		data, err = parser.EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+	}
+
+	_, err = parser.EnsureJSON(`ye`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+
+	_, err = NewJSONParser(true, literals).EnsureJSON(`{"a":N/A}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+
+	invalid := []map[string]any{
+		{"null": 0},
+		{"": 0},
+		{"bad": make(chan int)},
+	}
+
+	for _, entries := range invalid {
+		_, err = NewJSONParser(true, WithLenient(), WithLiterals(entries)).EnsureJSON(`{"a":1}`)
+		require.ErrorIs(t, err, ErrInvalidLiteral)
+	}
+}
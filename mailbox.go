@@ -0,0 +1,105 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import "sync"
+
+// mailbox is an unbounded queue delivering values to a channel, so that a slow
+// receiver never blocks the sender nor the other receivers
+type mailbox[T any] struct {
+	mu     sync.Mutex
+	items  []T
+	closed bool
+	wake   chan struct{}
+	done   chan struct{}
+	once   sync.Once
+	out    chan T
+}
+
+func newMailbox[T any]() *mailbox[T] {
+	m := &mailbox[T]{
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+		out:  make(chan T),
+	}
+	go m.run()
+
+	return m
+}
+
+// push queues a value
+func (m *mailbox[T]) push(v T) {
+	m.mu.Lock()
+	m.items = append(m.items, v)
+	m.mu.Unlock()
+	m.signal()
+}
+
+// close closes the channel once the queued values are delivered
+func (m *mailbox[T]) close() {
+	m.mu.Lock()
+	m.closed = true
+	m.mu.Unlock()
+	m.signal()
+}
+
+// stop discards the queued values and closes the channel
+func (m *mailbox[T]) stop() {
+	m.once.Do(func() {
+		close(m.done)
+	})
+}
+
+// stopped reports whether stop was called
+func (m *mailbox[T]) stopped() bool {
+	select {
+	case <-m.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *mailbox[T]) signal() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (m *mailbox[T]) run() {
+	defer close(m.out)
+	for {
+		m.mu.Lock()
+		if len(m.items) == 0 {
+			closed := m.closed
+			m.mu.Unlock()
+			if closed {
+				return
+			}
+
+			select {
+			case <-m.wake:
+			case <-m.done:
+				return
+			}
+			continue
+		}
+
+		v := m.items[0]
+		var zero T
+		m.items[0] = zero
+		m.items = m.items[1:]
+		m.mu.Unlock()
+
+		select {
+		case m.out <- v:
+		case <-m.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// markdownMarkers are the inline markdown wrappers removed from string values, longest first
+var markdownMarkers = []string{"***", "**", "__", "*", "`"}
+
+// WithStripMarkdown removes the markdown bold, italic and code wrappers models put around
+// string values, as in "**Alice**". A value wrapped as code that holds a number, a boolean
+// or null, as in "`42`", is replaced with that value. Keys are left as is
+func WithStripMarkdown() ParserOption {
+	return func(p *JSONParser) {
+		p.stripMarkdown = true
+	}
+}
+
+// unwrapMarkdown returns s without its inline markdown wrappers, and whether it was wrapped as code
+func unwrapMarkdown(s string) (string, bool) {
+	code := false
+	for {
+		marker := ""
+		for _, m := range markdownMarkers {
+			if len(s) > 2*len(m) && strings.HasPrefix(s, m) && strings.HasSuffix(s, m) {
+				marker = m
+				break
+			}
+		}
+		if marker == "" {
+			return s, code
+		}
+
+		inner := s[len(marker) : len(s)-len(marker)]
+		first, _ := utf8.DecodeRuneInString(inner)
+		last, _ := utf8.DecodeLastRuneInString(inner)
+		if unicode.IsSpace(first) || unicode.IsSpace(last) || strings.Contains(inner, marker) {
+			return s, code
+		}
+
+		s = inner
+		code = code || marker == "`"
+	}
+}
+
+// markdownValue returns the value of the string s without its markdown wrappers,
+// and whether s was wrapped
+func (p *JSONParser) markdownValue(s string) (any, bool) {
+	inner, code := unwrapMarkdown(s)
+	if inner == s {
+		return s, false
+	}
+
+	if code {
+		switch inner {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		case "null":
+			return nil, true
+		}
+
+		if len(inner) > 0 && (inner[0] == '-' || unicode.IsDigit(rune(inner[0]))) && json.Valid([]byte(inner)) {
+			num, err := strconv.ParseFloat(inner, 64)
+			if v, ok := p.literalNumber(inner, num, err); ok {
+				return v, true
+			}
+			if err == nil {
+				return num, true
+			}
+		}
+	}
+
+	return inner, true
+}
@@ -0,0 +1,49 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStripMarkdown(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		repairs         []Repair
+	}{
+		{
+			input:    `{"name":"**Alice**","age":"` + "`42`" + `","**note**":"*a* and *b*"}`,
+			expected: `{"**note**":"*a* and *b*","age":42,"name":"Alice"}`,
+			repairs: []Repair{
+				{Offset: 8, Original: `"**Alice**"`, Replacement: `"Alice"`, Kind: RepairStrippedMarkdown},
+				{Offset: 26, Original: `"` + "`42`" + `"`, Replacement: `42`, Kind: RepairStrippedMarkdown},
+			},
+		},
+		{
+			input:    `["***x***","` + "**`true`**" + `","* x *","**","_id_","**a *b* c**"]`,
+			expected: `["x",true,"* x *","**","_id_","a *b* c"]`,
+			repairs: []Repair{
+				{Offset: 1, Original: `"***x***"`, Replacement: `"x"`, Kind: RepairStrippedMarkdown},
+				{Offset: 11, Original: `"` + "**`true`**" + `"`, Replacement: `true`, Kind: RepairStrippedMarkdown},
+				{Offset: 44, Original: `"**a *b* c**"`, Replacement: `"a *b* c"`, Kind: RepairStrippedMarkdown},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var repairs []Repair
+		parser := NewJSONParser(true, WithStripMarkdown(), WithOnRepair(func(r Repair) {
+			repairs = append(repairs, r)
+		}))
+
+		data, err := parser.FastEnsureJSON(test.input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+		require.Equal(t, test.repairs, repairs)
+	}
+}
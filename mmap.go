@@ -0,0 +1,76 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// MappedFile is a read-only view of a file mapped in memory, whose pages are loaded by the
+// operating system as they are read rather than copied to the heap. On systems without mmap
+// support the file is read into memory instead
+type MappedFile struct {
+	data  []byte
+	unmap func() error
+}
+
+// OpenMapped maps the file at path in memory. The file must not be truncated while it is mapped
+func OpenMapped(path string) (*MappedFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fi.Size() == 0 {
+		return &MappedFile{unmap: func() error { return nil }}, nil
+	}
+
+	data, unmap, err := mapFile(f, fi.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	return &MappedFile{data: data, unmap: unmap}, nil
+}
+
+// Bytes returns the content of the file, valid until Close is called
+func (m *MappedFile) Bytes() []byte {
+	return m.data
+}
+
+// Reader returns a reader of the content of the file, for instance to feed RepairStream
+func (m *MappedFile) Reader() io.Reader {
+	return bytes.NewReader(m.data)
+}
+
+// Close unmaps the file. Neither the content returned by Bytes nor its readers can be used afterwards
+func (m *MappedFile) Close() error {
+	m.data = nil
+	return m.unmap()
+}
+
+// EnsureJSONFromFile is EnsureJSON reading the input from the file at path, mapped in memory
+// rather than read through intermediate buffers. The content is copied once into the input
+// parsed, so that the value, the errors and the strings passed to callbacks such as WithOnRepair
+// can be kept after the file is unmapped. RepairStream fed with the Reader of a MappedFile
+// repairs very large dumps without holding them in the heap
+func (p *JSONParser) EnsureJSONFromFile(path string) (string, error) {
+	m, err := OpenMapped(path)
+	if err != nil {
+		return "", err
+	}
+	defer m.Close()
+
+	return p.EnsureJSON(string(m.data))
+}
@@ -0,0 +1,47 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnsureJSONFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dump.json")
+	require.Nil(t, os.WriteFile(path, []byte(`{"events":[{"id":1,"msg":"boot"},{"id":2,"msg":"cra`), 0o644))
+
+	data, err := NewJSONParser(false).EnsureJSONFromFile(path)
+	require.Nil(t, err)
+	require.Equal(t, `{"events":[{"id":1,"msg":"boot"},{"id":2,"msg":"cra"}]}`, data)
+
+	m, err := OpenMapped(path)
+	require.Nil(t, err)
+	var out bytes.Buffer
+	require.Nil(t, NewJSONParser(false).RepairStream(&out, m.Reader()))
+	require.Nil(t, m.Close())
+	require.Equal(t, data, out.String())
+
+	// the token of the error is read after the file is unmapped
+	broken := filepath.Join(t.TempDir(), "broken.json")
+	require.Nil(t, os.WriteFile(broken, []byte(`{"a":[1,2} `), 0o644))
+	_, err = NewJSONParser(true).EnsureJSONFromFile(broken)
+	var perr *ParseError
+	require.ErrorAs(t, err, &perr)
+	require.Equal(t, "}", perr.Token)
+
+	empty := filepath.Join(t.TempDir(), "empty.json")
+	require.Nil(t, os.WriteFile(empty, nil, 0o644))
+	_, err = NewJSONParser(false).EnsureJSONFromFile(empty)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+
+	_, err = NewJSONParser(false).EnsureJSONFromFile(filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
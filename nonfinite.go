@@ -0,0 +1,74 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNonFiniteNumber is returned when NaN or Infinity is found with NonFiniteFail
+var ErrNonFiniteNumber = errors.New("non-finite number")
+
+// NonFiniteMode controls what NaN, Infinity and -Infinity accepted by WithAllowNaNInf become
+type NonFiniteMode int
+
+const (
+	// NonFiniteNull turns them into null. It is the default behavior
+	NonFiniteNull NonFiniteMode = iota
+	// NonFiniteString turns them into the strings "NaN", "Infinity" and "-Infinity"
+	NonFiniteString
+	// NonFiniteFail fails with a *ParseError wrapping ErrNonFiniteNumber at the offset of the token
+	NonFiniteFail
+)
+
+// nonFiniteTokens are the tokens accepted by WithAllowNaNInf
+var nonFiniteTokens = []string{"NaN", "Infinity", "-Infinity"}
+
+// WithAllowNaNInf accepts the tokens NaN, Infinity and -Infinity found outside strings, as emitted
+// by Python and JavaScript serializers, turning them into what mode sets since JSON can not represent
+// them. A token cut by the end of the input is recorded as a truncated number. Every replaced token
+// is reported as a repair
+func WithAllowNaNInf(mode NonFiniteMode) ParserOption {
+	return func(p *JSONParser) {
+		p.allowNonFinite = true
+		p.nonFinite = mode
+	}
+}
+
+// parseNonFinite parses a token accepted by WithAllowNaNInf
+func (p *JSONParser) parseNonFinite(s string) (any, string, error) {
+	for _, token := range nonFiniteTokens {
+		n := len(token)
+		if strings.HasPrefix(s, token) && (n == len(s) || !identByte(s[n])) {
+			return p.nonFiniteValue(s, token)
+		}
+		if strings.HasPrefix(token, s) {
+			p.truncate(s, KindNumber, nil)
+			return nil, s, ErrUnexpectedToken
+		}
+	}
+
+	if p.pythonLiterals && s[0] == 'N' {
+		return p.parsePythonLiteral(s)
+	}
+	return nil, s, ErrUnexpectedToken
+}
+
+// nonFiniteValue returns the value token found at the start of s stands for according to the non-finite mode
+func (p *JSONParser) nonFiniteValue(s, token string) (any, string, error) {
+	switch p.nonFinite {
+	case NonFiniteString:
+		p.repair(RepairNonFiniteNumber, s, token, `"`+token+`"`)
+		return token, s[len(token):], nil
+	case NonFiniteFail:
+		return nil, s, &ParseError{Offset: p.offset(s), Err: ErrNonFiniteNumber}
+	}
+
+	p.repair(RepairNonFiniteNumber, s, token, "null")
+	return nil, s[len(token):], nil
+}
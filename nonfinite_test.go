@@ -0,0 +1,58 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAllowNaNInf(t *testing.T) {
+	input := `{"a":NaN,"b":[Infinity,-Infinity,-1],"c":"NaN"}`
+	tests := []struct {
+		mode     NonFiniteMode
+		expected string
+	}{
+		{mode: NonFiniteNull, expected: `{"a":null,"b":[null,null,-1],"c":"NaN"}`},
+		{mode: NonFiniteString, expected: `{"a":"NaN","b":["Infinity","-Infinity",-1],"c":"NaN"}`},
+	}
+
+	for _, test := range tests {
+		parser := NewJSONParser(true, WithAllowNaNInf(test.mode))
+		data, err := parser.EnsureJSON(input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+
+		data, err = parser.FastEnsureJSON(input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+	}
+
+	var repairs []Repair
+	parser := NewJSONParser(true, WithAllowNaNInf(NonFiniteNull), WithOnRepair(func(r Repair) {
+		repairs = append(repairs, r)
+	}))
+	_, err := parser.EnsureJSON(`[1,-Infinity]`)
+	require.Nil(t, err)
+	require.Equal(t, []Repair{{Offset: 3, Original: "-Infinity", Replacement: "null", Kind: RepairNonFiniteNumber}}, repairs)
+
+	_, status, err := parser.ParseWithStatus(`{"a":1,"b":-Infin`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+	require.Equal(t, KindNumber, status.Kind)
+
+	data, err := NewJSONParser(true, WithAllowNaNInf(NonFiniteFail)).EnsureJSON(`{"a":1,"b":NaN}`)
+	require.ErrorIs(t, err, ErrNonFiniteNumber)
+	require.Equal(t, &ParseError{Offset: 11, Line: 1, Column: 12, Token: "NaN", Err: ErrNonFiniteNumber}, err)
+	require.Equal(t, `{"a":1}`, data)
+
+	data, err = NewJSONParser(true, WithAllowNaNInf(NonFiniteNull), WithPythonLiterals()).EnsureJSON(`[NaN,None]`)
+	require.Nil(t, err)
+	require.Equal(t, `[null,null]`, data)
+
+	_, err = NewJSONParser(true).EnsureJSON(`{"a":NaN}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
@@ -0,0 +1,82 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"iter"
+)
+
+// ollamaLine holds the members of the streamed lines used by OllamaStream
+type ollamaLine struct {
+	// Response is the text of an Ollama /api/generate line
+	Response string `json:"response"`
+	// Message is the message of an Ollama /api/chat line
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	// Content is the text of a llama.cpp /completion line
+	Content string `json:"content"`
+	Done    bool   `json:"done"`
+	Stop    bool   `json:"stop"`
+	Error   string `json:"error"`
+}
+
+// OllamaError is an error reported in the stream of an Ollama or llama.cpp server
+type OllamaError string
+
+func (e OllamaError) Error() string {
+	return string(e)
+}
+
+// OllamaStream yields the repaired snapshots of the JSON answer streamed by an Ollama or llama.cpp
+// server decoded into T, r being the body of the response. The line-delimited format of Ollama, as
+// in {"response":"...","done":false}, and the server-sent events of llama.cpp, as in data: {"content":"..."},
+// are both read, the text of the lines being accumulated and repaired with p until the done line
+func OllamaStream[T any](p *JSONParser, r io.Reader) iter.Seq2[T, error] {
+	return streamJSON[T](NewStreamDecoder(p), ollamaLines(r), func(line ollamaLine) string {
+		return line.Response + line.Message.Content + line.Content
+	})
+}
+
+// ollamaLines returns the sequence of the lines read from r up to the done line
+func ollamaLines(r io.Reader) iter.Seq2[ollamaLine, error] {
+	return func(yield func(ollamaLine, error) bool) {
+		br := bufio.NewReader(r)
+		for {
+			raw, err := br.ReadBytes('\n')
+			if err != nil && err != io.EOF {
+				yield(ollamaLine{}, err)
+				return
+			}
+
+			raw = bytes.TrimSpace(raw)
+			raw = bytes.TrimSpace(bytes.TrimPrefix(raw, []byte("data:")))
+			if len(raw) > 0 {
+				var line ollamaLine
+				if jerr := json.Unmarshal(raw, &line); jerr != nil {
+					yield(line, jerr)
+					return
+				}
+				if line.Error != "" {
+					yield(line, OllamaError(line.Error))
+					return
+				}
+				if !yield(line, nil) || line.Done || line.Stop {
+					return
+				}
+			}
+
+			if err == io.EOF {
+				return
+			}
+		}
+	}
+}
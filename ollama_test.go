@@ -0,0 +1,68 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestOllamaStream(t *testing.T) {
+	type answer struct {
+		Options []string `json:"options"`
+	}
+
+	tests := []struct {
+		body     string
+		expected []answer
+		err      error
+	}{
+		{
+			body: `{"model":"llama3","response":"{\"options\":[\"接","done":false}
+{"model":"llama3","response":"受\",\"拒","done":false}
+
+{"model":"llama3","response":"绝\"]}","done":true}
+{"model":"llama3","response":"ignored","done":false}
+`,
+			expected: []answer{{Options: []string{"接"}}, {Options: []string{"接受", "拒"}}, {Options: []string{"接受", "拒绝"}}},
+		},
+		{
+			body:     "{\"message\":{\"role\":\"assistant\",\"content\":\"{\\\"options\\\":[\\\"a\"},\"done\":false}\n{\"error\":\"model unloaded\"}\n",
+			expected: []answer{{Options: []string{"a"}}},
+			err:      OllamaError("model unloaded"),
+		},
+		{
+			body:     "data: {\"content\":\"{\\\"options\\\":[\\\"b\\\"]}\",\"stop\":false}\n\ndata: {\"content\":\"\",\"stop\":true}\n\n",
+			expected: []answer{{Options: []string{"b"}}},
+		},
+	}
+
+	for _, test := range tests {
+		var answers []answer
+		var err error
+		for a, aerr := range OllamaStream[answer](NewJSONParser(false), strings.NewReader(test.body)) {
+			if aerr != nil {
+				err = aerr
+				continue
+			}
+			answers = append(answers, a)
+		}
+		require.Equal(t, test.expected, answers)
+		require.Equal(t, test.err, err)
+	}
+
+	body := `{"response":"{\"x\":bad,\"options\":[\"a","done":false}
+{"response":"\",\"b\"]}","done":true}
+`
+	var answers []answer
+	for a, err := range OllamaStream[answer](NewJSONParser(false, WithLenient()), strings.NewReader(body)) {
+		require.ErrorIs(t, err, ErrUnexpectedToken)
+		answers = append(answers, a)
+	}
+	require.Equal(t, []answer{{Options: []string{"a"}}, {Options: []string{"a", "b"}}}, answers)
+}
@@ -0,0 +1,84 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+)
+
+// OnPath calls fn whenever a value matching path gains a new or updated value as the document grows,
+// so that it can be rendered field by field. A # segment matches any member or element, as in
+// scene_list.#.chat_group.#.content, \# standing for a member named #, and fn receives the path
+// of the value that changed.
+// A value is reported while it is still being streamed, but not before it has started
+func (d *StreamDecoder) OnPath(path string, fn func(path string, v any)) {
+	segs := parsePattern(path)
+	last := make(map[string][]byte)
+	d.watchers = append(d.watchers, &watcher{
+		notify: func(snapshot Snapshot, tr *truncation) (bool, error) {
+			eachMatch(snapshot.Data, segs, nil, func(found []any, v any) {
+				if v == nil && tr.covers(found) {
+					return
+				}
+
+				b, err := json.Marshal(v)
+				key := formatPath(found)
+				if err != nil || bytes.Equal(b, last[key]) {
+					return
+				}
+
+				last[key] = b
+				fn(key, v)
+			})
+			return !snapshot.Complete, nil
+		},
+		close: func() {},
+	})
+}
+
+// eachMatch calls fn with the path and the value of every value found in v at the pattern segs.
+// Members are visited in name order
+func eachMatch(v any, segs []segment, path []any, fn func(path []any, v any)) {
+	if len(segs) == 0 {
+		fn(path, v)
+		return
+	}
+
+	seg := segs[0]
+	switch val := v.(type) {
+	case map[string]any:
+		if !seg.wildcard {
+			if child, ok := val[seg.name]; ok {
+				eachMatch(child, segs[1:], append(path, seg.name), fn)
+			}
+			return
+		}
+
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			eachMatch(val[key], segs[1:], append(path, key), fn)
+		}
+	case []any:
+		if !seg.wildcard {
+			if i, err := strconv.Atoi(seg.name); err == nil && i >= 0 && i < len(val) {
+				eachMatch(val[i], segs[1:], append(path, i), fn)
+			}
+			return
+		}
+
+		for i, elem := range val {
+			eachMatch(elem, segs[1:], append(path, i), fn)
+		}
+	}
+}
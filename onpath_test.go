@@ -0,0 +1,52 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestOnPath(t *testing.T) {
+	dec := NewStreamDecoder(NewJSONParser(false))
+
+	var events []string
+	dec.OnPath("scene_list.#.chat_group.#.content", func(path string, v any) {
+		events = append(events, path+"="+v.(string))
+	})
+
+	chunks := []string{
+		`{"scene_list":[{"chat_group":[{"content":"我`,
+		`盯着"},{"role":"a","content":`,
+		`"墨镜"}]},{"chat_group":[{"con`,
+		`tent":"下一场"}]}]}`,
+	}
+	for _, chunk := range chunks {
+		_, err := dec.Feed([]byte(chunk))
+		require.Nil(t, err)
+	}
+
+	require.Equal(t, []string{
+		"scene_list.0.chat_group.0.content=我",
+		"scene_list.0.chat_group.0.content=我盯着",
+		"scene_list.0.chat_group.1.content=墨镜",
+		"scene_list.1.chat_group.0.content=下一场",
+	}, events)
+}
+
+func TestOnPathEscapedWildcard(t *testing.T) {
+	dec := NewStreamDecoder(NewJSONParser(false))
+
+	var events []string
+	dec.OnPath(`tags.\#`, func(path string, v any) {
+		events = append(events, path+"="+v.(string))
+	})
+
+	_, err := dec.Feed([]byte(`{"tags":{"a":"b","#":"hash"}}`))
+	require.Nil(t, err)
+	require.Equal(t, []string{`tags.\#=hash`}, events)
+}
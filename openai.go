@@ -0,0 +1,99 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"iter"
+	"reflect"
+)
+
+// OpenAIStream yields the repaired snapshots of the JSON answer of a streamed chat completion
+// decoded into T, stream being the ssestream.Stream[openai.ChatCompletionChunk] returned by
+// client.Chat.Completions.NewStreaming of the OpenAI Go SDK. The content deltas of the first
+// choice are accumulated and repaired with p
+func OpenAIStream[T, C any](p *JSONParser, stream ChunkStream[C]) iter.Seq2[T, error] {
+	return StreamJSON[T](NewStreamDecoder(p), stream, OpenAIContent[C])
+}
+
+// OpenAIContent returns the content delta of the first choice of a chat completion chunk,
+// that is chunk.Choices[0].Delta.Content, or "" if the chunk has none
+func OpenAIContent[C any](chunk C) string {
+	choices := field(reflect.ValueOf(chunk), "Choices")
+	if !choices.IsValid() || choices.Kind() != reflect.Slice || choices.Len() == 0 {
+		return ""
+	}
+
+	content := field(field(choices.Index(0), "Delta"), "Content")
+	if !content.IsValid() || content.Kind() != reflect.String {
+		return ""
+	}
+
+	return content.String()
+}
+
+// OpenAIToolCalls feeds the tool call deltas of the first choice of a chat completion chunk to d and
+// returns the updated calls, chunk being an openai.ChatCompletionChunk of the OpenAI Go SDK. The Index,
+// ID, Function.Name and Function.Arguments of every element of chunk.Choices[0].Delta.ToolCalls are
+// passed to Feed, so that the arguments of each call are repaired on their own. The errors of the
+// arguments that can not be repaired yet and the problems skipped over in lenient mode are returned
+// joined, the calls being updated and the other deltas fed nonetheless
+func OpenAIToolCalls[C any](d *ToolCallDemux, chunk C) ([]ToolCall, error) {
+	choices := field(reflect.ValueOf(chunk), "Choices")
+	if !choices.IsValid() || choices.Kind() != reflect.Slice || choices.Len() == 0 {
+		return nil, nil
+	}
+
+	deltas := field(field(choices.Index(0), "Delta"), "ToolCalls")
+	if !deltas.IsValid() || deltas.Kind() != reflect.Slice {
+		return nil, nil
+	}
+
+	var calls []ToolCall
+	var errs []error
+	for i := 0; i < deltas.Len(); i++ {
+		delta := deltas.Index(i)
+		index := i
+		if v := field(delta, "Index"); v.IsValid() && v.CanInt() {
+			index = int(v.Int())
+		}
+
+		fn := field(delta, "Function")
+		call, err := d.Feed(index, stringField(delta, "ID"), stringField(fn, "Name"), stringField(fn, "Arguments"))
+		if err != nil {
+			errs = append(errs, err)
+		}
+		calls = append(calls, call)
+	}
+
+	return calls, errors.Join(errs...)
+}
+
+// stringField returns the string field name of the struct v, or "" if it has none
+func stringField(v reflect.Value, name string) string {
+	f := field(v, name)
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+
+	return f.String()
+}
+
+// field returns the field name of the struct v, dereferencing pointers, or the zero Value
+func field(v reflect.Value, name string) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	return v.FieldByName(name)
+}
@@ -0,0 +1,174 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// fakeStream replays chunks like the ssestream.Stream of the model SDKs
+type fakeStream[C any] struct {
+	chunks []C
+	i      int
+	err    error
+}
+
+func (s *fakeStream[C]) Next() bool {
+	s.i++
+	return s.i <= len(s.chunks)
+}
+
+func (s *fakeStream[C]) Current() C {
+	return s.chunks[s.i-1]
+}
+
+func (s *fakeStream[C]) Err() error {
+	return s.err
+}
+
+// openAIChunk mirrors the shape of openai.ChatCompletionChunk
+type openAIChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string
+		}
+	}
+}
+
+func newOpenAIChunk(content string) openAIChunk {
+	var chunk openAIChunk
+	chunk.Choices = make([]struct{ Delta struct{ Content string } }, 1)
+	chunk.Choices[0].Delta.Content = content
+	return chunk
+}
+
+func TestOpenAIStream(t *testing.T) {
+	type answer struct {
+		Question string   `json:"question"`
+		Options  []string `json:"options"`
+	}
+
+	stream := &fakeStream[openAIChunk]{chunks: []openAIChunk{
+		newOpenAIChunk(`{"question":"如何`),
+		{},
+		newOpenAIChunk(`面对？","options":["接受`),
+		newOpenAIChunk(`挑战"],"x":tr`),
+		newOpenAIChunk(`ue}`),
+	}}
+
+	var answers []answer
+	for a, err := range OpenAIStream[answer](NewJSONParser(false), stream) {
+		require.Nil(t, err)
+		answers = append(answers, a)
+	}
+	require.Equal(t, []answer{
+		{Question: "如何"},
+		{Question: "如何面对？", Options: []string{"接受"}},
+		{Question: "如何面对？", Options: []string{"接受挑战"}},
+	}, answers)
+
+	stream = &fakeStream[openAIChunk]{chunks: []openAIChunk{newOpenAIChunk(`{"question":"x`)}, err: errors.New("reset")}
+	var errs []error
+	for _, err := range OpenAIStream[answer](NewJSONParser(false), stream) {
+		errs = append(errs, err)
+	}
+	require.Equal(t, []error{nil, stream.err}, errs)
+
+	stream = &fakeStream[openAIChunk]{chunks: []openAIChunk{
+		newOpenAIChunk(`{"question":"x","options":bad,`),
+		newOpenAIChunk(`"extra":1`),
+		newOpenAIChunk(`,"question":"y"}`),
+	}}
+	answers = answers[:0]
+	for a, err := range OpenAIStream[answer](NewJSONParser(false, WithLenient()), stream) {
+		require.ErrorIs(t, err, ErrUnexpectedToken)
+		answers = append(answers, a)
+	}
+	require.Equal(t, []answer{{Question: "x"}, {Question: "x"}, {Question: "y"}}, answers)
+
+	require.Equal(t, "", OpenAIContent(&openAIChunk{}))
+}
+
+// openAIToolCallChunk mirrors the shape of the tool call deltas of openai.ChatCompletionChunk
+type openAIToolCallChunk struct {
+	Choices []struct {
+		Delta struct {
+			ToolCalls []openAIToolCallDelta
+		}
+	}
+}
+
+type openAIToolCallDelta struct {
+	Index    int64
+	ID       string
+	Function struct {
+		Name      string
+		Arguments string
+	}
+}
+
+func newOpenAIToolCallChunk(deltas ...openAIToolCallDelta) openAIToolCallChunk {
+	var chunk openAIToolCallChunk
+	chunk.Choices = make([]struct {
+		Delta struct{ ToolCalls []openAIToolCallDelta }
+	}, 1)
+	chunk.Choices[0].Delta.ToolCalls = deltas
+	return chunk
+}
+
+func newOpenAIToolCallDelta(index int64, id, name, arguments string) openAIToolCallDelta {
+	delta := openAIToolCallDelta{Index: index, ID: id}
+	delta.Function.Name = name
+	delta.Function.Arguments = arguments
+	return delta
+}
+
+func TestOpenAIToolCalls(t *testing.T) {
+	var completed []string
+	demux := NewToolCallDemux(NewJSONParser(false), func(call ToolCall) {
+		completed = append(completed, call.Name)
+	})
+
+	calls, err := OpenAIToolCalls(demux, newOpenAIToolCallChunk(
+		newOpenAIToolCallDelta(0, "call_1", "get_weather", `{"city":"Par`),
+		newOpenAIToolCallDelta(1, "call_2", "get_time", `{"tz":tr`),
+	))
+	require.NotNil(t, err)
+	require.Len(t, calls, 2)
+	require.Equal(t, `{"city":"Par"}`, calls[0].Snapshot.JSON)
+
+	calls, err = OpenAIToolCalls(demux, newOpenAIToolCallChunk(newOpenAIToolCallDelta(1, "", "", `ue}`)))
+	require.Nil(t, err)
+	require.Equal(t, "get_time", calls[0].Name)
+	require.Equal(t, `{"tz":true}`, calls[0].Snapshot.JSON)
+
+	calls, err = OpenAIToolCalls(demux, newOpenAIChunk("text"))
+	require.Nil(t, err)
+	require.Empty(t, calls)
+
+	_, err = OpenAIToolCalls(demux, &openAIToolCallChunk{})
+	require.Nil(t, err)
+
+	call, ok := demux.Call(0)
+	require.True(t, ok)
+	require.Equal(t, "call_1", call.ID)
+	require.False(t, call.Snapshot.Complete)
+	_, ok = demux.Call(2)
+	require.False(t, ok)
+	require.Equal(t, []string{"get_time"}, completed)
+
+	demux = NewToolCallDemux(NewJSONParser(false, WithLenient()), nil)
+	calls, err = OpenAIToolCalls(demux, newOpenAIToolCallChunk(
+		newOpenAIToolCallDelta(0, "call_1", "get_weather", `{"city":"Paris","x":bad,`),
+		newOpenAIToolCallDelta(1, "call_2", "get_time", `{"tz":"UTC"}`),
+	))
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+	require.Equal(t, `{"city":"Paris"}`, calls[0].Snapshot.JSON)
+	require.Equal(t, `{"tz":"UTC"}`, calls[1].Snapshot.JSON)
+}
@@ -0,0 +1,44 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is returned instead of crashing when the parser panics on an input
+type PanicError struct {
+	// Value is the value the parser panicked with
+	Value any
+	// Input is the input being parsed
+	Input string
+	// Stack is the stack trace of the panic
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic while parsing %d bytes of input: %v", len(e.Input), e.Value)
+}
+
+// Unwrap returns the value the parser panicked with when it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// catchPanic converts a panic raised while parsing input into a *PanicError stored in err.
+// It must be deferred directly
+func catchPanic(input string, err *error) {
+	if r := recover(); r != nil {
+		*err = &PanicError{
+			Value: r,
+			Input: input,
+			Stack: debug.Stack(),
+		}
+	}
+}
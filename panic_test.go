@@ -0,0 +1,45 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCatchPanic(t *testing.T) {
+	parser := NewJSONParser(true)
+	parser.parsers['['] = func(p *JSONParser, s string) (any, string, error) {
+		return nil, s[len(s)+1:], nil
+	}
+
+	input := `{"a":[1,2`
+	data, err := parser.EnsureJSON(input)
+	require.Equal(t, "", data)
+
+	var perr *PanicError
+	require.True(t, errors.As(err, &perr))
+	require.Equal(t, input, perr.Input)
+	require.NotEmpty(t, perr.Stack)
+	require.Contains(t, err.Error(), "slice bounds out of range")
+
+	var v map[string]any
+	require.True(t, errors.As(parser.Unmarshal([]byte(input), &v), &perr))
+
+	parser = NewJSONParser(true, WithOnExtraToken(func(string, any, string) {
+		panic("boom")
+	}))
+	_, err = parser.EnsureJSON(`{"a":1} x`)
+	require.True(t, errors.As(err, &perr))
+	require.Equal(t, "boom", perr.Value)
+	require.Nil(t, perr.Unwrap())
+
+	dec := NewStreamDecoder(parser)
+	_, err = dec.Feed([]byte(`{"a":1} x`))
+	require.True(t, errors.As(err, &perr))
+}
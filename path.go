@@ -0,0 +1,157 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"strconv"
+	"strings"
+)
+
+// wildcard is the segment of a path matching any member or element, written #
+type wildcard struct{}
+
+// segment is a segment of a path pattern, a member name or an element index, or a wildcard
+type segment struct {
+	name     string
+	wildcard bool
+}
+
+// formatPath formats a path of member names and element indexes with the dotted syntax,
+// e.g. scene_list.0.chat_group. Dots and backslashes in member names are escaped with a
+// backslash, as is the member named #, which a pattern would read as a wildcard
+func formatPath(path []any) string {
+	var sb strings.Builder
+	for i, seg := range path {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+
+		switch seg := seg.(type) {
+		case int:
+			sb.WriteString(strconv.Itoa(seg))
+		case wildcard:
+			sb.WriteByte('#')
+		case string:
+			if seg == "#" {
+				sb.WriteByte('\\')
+			}
+			for j := 0; j < len(seg); j++ {
+				if seg[j] == '.' || seg[j] == '\\' {
+					sb.WriteByte('\\')
+				}
+				sb.WriteByte(seg[j])
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// parsePath splits a path in the dotted syntax into its segments
+func parsePath(path string) []string {
+	var segs []string
+	for _, seg := range parsePattern(path) {
+		segs = append(segs, seg.name)
+	}
+
+	return segs
+}
+
+// parsePattern splits a path pattern in the dotted syntax into its segments, a # segment being a
+// wildcard matching any member or element, and \# the member named #
+func parsePattern(path string) []segment {
+	if path == "" {
+		return nil
+	}
+
+	var segs []segment
+	var sb strings.Builder
+	escaped := false
+	flush := func() {
+		name := sb.String()
+		segs = append(segs, segment{name: name, wildcard: name == "#" && !escaped})
+		sb.Reset()
+		escaped = false
+	}
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '\\' && i+1 < len(path):
+			i++
+			sb.WriteByte(path[i])
+			escaped = true
+		case c == '.':
+			flush()
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	flush()
+
+	return segs
+}
+
+// lookupPath returns the value found at the path segs in v. Segments are member
+// names for objects and element indexes for arrays
+func lookupPath(v any, segs []string) (any, bool) {
+	for _, seg := range segs {
+		switch val := v.(type) {
+		case map[string]any:
+			child, ok := val[seg]
+			if !ok {
+				return nil, false
+			}
+			v = child
+		case []any:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= len(val) {
+				return nil, false
+			}
+			v = val[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return v, true
+}
+
+// pathHasPrefix reports whether the path of member names and element indexes starts with the segments segs
+func pathHasPrefix(path []any, segs []string) bool {
+	if len(path) < len(segs) {
+		return false
+	}
+
+	for i, seg := range segs {
+		switch p := path[i].(type) {
+		case int:
+			if strconv.Itoa(p) != seg {
+				return false
+			}
+		case string:
+			if p != seg {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// matchPath reports whether the path of member names and element indexes matches the pattern segs
+func matchPath(path []any, segs []segment) bool {
+	if len(path) != len(segs) {
+		return false
+	}
+
+	for i, seg := range segs {
+		if !seg.wildcard && !pathHasPrefix(path[i:i+1], []string{seg.name}) {
+			return false
+		}
+	}
+
+	return true
+}
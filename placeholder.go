@@ -0,0 +1,50 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"maps"
+	"slices"
+)
+
+// WithPlaceholder sets the value yielded instead of null by a member or element of the given kind cut
+// by the end of the input, such as "" for KindString, 0 for KindNumber, []any{} for KindArray or
+// map[string]any{} for KindObject, so that typed consumers need not handle null. KindUnknown sets the
+// value of a key the input ends after. With a placeholder, a number or a literal such as true cut by
+// the end of the input no longer fails. It can be set for several kinds
+func WithPlaceholder(kind Kind, v any) ParserOption {
+	return func(p *JSONParser) {
+		p.placeholders = maps.Clone(p.placeholders)
+		if p.placeholders == nil {
+			p.placeholders = make(map[Kind]any)
+		}
+		p.placeholders[kind] = v
+	}
+}
+
+// placeholder returns the placeholder of the value at the current path when it is the value the input ended in
+func (p *JSONParser) placeholder() (any, bool) {
+	tr := p.state.truncation
+	if len(p.placeholders) == 0 || tr == nil || len(tr.path) != len(p.state.path) || !tr.covers(p.state.path) {
+		return nil, false
+	}
+
+	v, ok := p.placeholders[tr.kind]
+	if !ok {
+		return nil, false
+	}
+
+	// containers are copied, so that the values parsed never share them
+	switch v := v.(type) {
+	case []any:
+		return slices.Clone(v), true
+	case map[string]any:
+		return maps.Clone(v), true
+	}
+
+	return v, true
+}
@@ -0,0 +1,61 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPlaceholder(t *testing.T) {
+	parser := NewJSONParser(true,
+		WithPlaceholder(KindString, ""),
+		WithPlaceholder(KindNumber, 0),
+		WithPlaceholder(KindBool, false),
+		WithPlaceholder(KindArray, []any{}),
+		WithPlaceholder(KindObject, map[string]any{}),
+		WithPlaceholder(KindUnknown, ""),
+	)
+
+	tests := []struct {
+		input, expected string
+	}{
+		{input: `{"name":"Al`, expected: `{"name":""}`},
+		{input: `{"tags":["a","b`, expected: `{"tags":["a",""]}`},
+		{input: `{"age":-`, expected: `{"age":0}`},
+		{input: `{"n":[1,2e`, expected: `{"n":[1,0]}`},
+		{input: `{"ok":tr`, expected: `{"ok":false}`},
+		{input: `{"items":[`, expected: `{"items":[]}`},
+		{input: `{"user":{"a":1},"owner":{`, expected: `{"owner":{},"user":{"a":1}}`},
+		{input: `{"name":`, expected: `{"name":""}`},
+		{input: `{"name":"Al","age":3`, expected: `{"age":3,"name":"Al"}`},
+		{input: `{"a":null`, expected: `{"a":null}`},
+	}
+
+	for _, test := range tests {
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+
+		data, err = parser.FastEnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+	}
+
+	var obj struct {
+		Name  string   `json:"name"`
+		Age   int      `json:"age"`
+		Items []string `json:"items"`
+	}
+	require.Nil(t, parser.Unmarshal([]byte(`{"name":"Bob","age":4,"items":[`), &obj))
+	require.Equal(t, 4, obj.Age)
+	require.NotNil(t, obj.Items)
+
+	data, err := NewJSONParser(true, WithPlaceholder(KindString, "")).EnsureJSON(`{"a":-`)
+	require.ErrorIs(t, err, ErrIncompleteNum)
+	require.Equal(t, `{}`, data)
+}
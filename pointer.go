@@ -0,0 +1,53 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidPointer is returned when a JSON Pointer is not valid
+var ErrInvalidPointer = errors.New("invalid JSON pointer")
+
+// pointerUnescaper unescapes the reference tokens of a JSON Pointer, ~1 before ~0 as RFC 6901 requires
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// Pointer returns the value referenced by the RFC 6901 JSON Pointer ptr in the partial document s,
+// e.g. /scene_list/0/chat_group, as Get does for a dotted path. The empty pointer references the
+// whole document, and the - index, referencing the element after the last one, never exists
+func (p *JSONParser) Pointer(s, ptr string) (Result, error) {
+	segs, err := parsePointer(ptr)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return p.get(s, segs)
+}
+
+// parsePointer splits the JSON Pointer ptr into its unescaped reference tokens
+func parsePointer(ptr string) ([]string, error) {
+	if ptr == "" {
+		return nil, nil
+	}
+	if ptr[0] != '/' {
+		return nil, fmt.Errorf("%w: %q does not start with /", ErrInvalidPointer, ptr)
+	}
+
+	segs := strings.Split(ptr[1:], "/")
+	for i, seg := range segs {
+		for j := 0; j < len(seg); j++ {
+			if seg[j] == '~' && (j+1 == len(seg) || seg[j+1] != '0' && seg[j+1] != '1') {
+				return nil, fmt.Errorf("%w: %q has an invalid escape", ErrInvalidPointer, ptr)
+			}
+		}
+		segs[i] = pointerUnescaper.Replace(seg)
+	}
+
+	return segs, nil
+}
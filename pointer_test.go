@@ -0,0 +1,71 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPointer(t *testing.T) {
+	const doc = `{"scene_list": [{"chat_group": [{"content": "hi"}, {"content": "Hel`
+
+	tests := []struct {
+		ptr      string
+		expected Result
+	}{
+		{
+			ptr:      "/scene_list/0/chat_group/0/content",
+			expected: Result{Value: "hi", JSON: `"hi"`, Exists: true, Complete: true},
+		},
+		{
+			ptr:      "/scene_list/0/chat_group/1/content",
+			expected: Result{Value: "Hel", JSON: `"Hel"`, Exists: true},
+		},
+		{
+			ptr:      "/scene_list/0/chat_group/2/content",
+			expected: Result{},
+		},
+		{
+			ptr:      "/scene_list/-",
+			expected: Result{},
+		},
+		{
+			ptr: "",
+			expected: Result{
+				Value:  map[string]any{"scene_list": []any{map[string]any{"chat_group": []any{map[string]any{"content": "hi"}, map[string]any{"content": "Hel"}}}}},
+				JSON:   `{"scene_list":[{"chat_group":[{"content":"hi"},{"content":"Hel"}]}]}`,
+				Exists: true,
+			},
+		},
+	}
+
+	parser := NewJSONParser(false)
+	for _, test := range tests {
+		res, err := parser.Pointer(doc, test.ptr)
+		require.Nil(t, err, test.ptr)
+		require.Equal(t, test.expected, res, test.ptr)
+	}
+}
+
+func TestPointerEscapes(t *testing.T) {
+	parser := NewJSONParser(false)
+
+	res, err := parser.Pointer(`{"a/b": {"m~n": 1, "c.d": 2}}`, "/a~1b/m~0n")
+	require.Nil(t, err)
+	require.Equal(t, Result{Value: float64(1), JSON: `1`, Exists: true, Complete: true}, res)
+
+	res, err = parser.Pointer(`{"a/b": {"m~n": 1, "c.d": 2}}`, "/a~1b/c.d")
+	require.Nil(t, err)
+	require.Equal(t, Result{Value: float64(2), JSON: `2`, Exists: true, Complete: true}, res)
+
+	_, err = parser.Pointer(`{}`, "a")
+	require.ErrorIs(t, err, ErrInvalidPointer)
+
+	_, err = parser.Pointer(`{}`, "/a~2")
+	require.ErrorIs(t, err, ErrInvalidPointer)
+}
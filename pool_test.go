@@ -0,0 +1,80 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+func TestParserPool(t *testing.T) {
+	parser := NewJSONParser(false, WithKeyOrder())
+	pool := NewParserPool(parser)
+
+	inputs := []string{
+		`{"b": 1, "a": [1, {"c": "x`,
+		`{"z": true, "y": null}`,
+		`[1, 2, {"k": "v"`,
+		`{"a": 1}}`,
+	}
+	for i := 0; i < 3; i++ {
+		for _, input := range inputs {
+			expected, expectedErr := parser.EnsureJSON(input)
+			res, err := pool.EnsureJSON(input)
+			require.Equal(t, expectedErr, err, input)
+			require.Equal(t, expected, res, input)
+		}
+	}
+
+	var v struct {
+		B int
+		A []any
+	}
+	require.Nil(t, pool.Unmarshal([]byte(inputs[0]), &v))
+	require.Equal(t, 1, v.B)
+	require.Equal(t, []any{float64(1), map[string]any{"c": "x"}}, v.A)
+
+	require.ErrorIs(t, pool.Unmarshal([]byte(`]`), &v), ErrUnexpectedToken)
+}
+
+func TestParserPoolConcurrentUse(t *testing.T) {
+	pool := NewParserPool(NewJSONParser(true))
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				if res, err := pool.EnsureJSON(`{"a": [1, 2, {"b": "c"`); err != nil || res != `{"a":[1,2,{"b":"c"}]}` {
+					t.Errorf("EnsureJSON() = %q, %v", res, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkParserPool(b *testing.B) {
+	const input = `{"id": "chatcmpl-1", "choices": [{"index": 0, "delta": {"content": "Hello, wor`
+	parser := NewJSONParser(false)
+	pool := NewParserPool(parser)
+
+	b.Run("parser", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = parser.EnsureJSON(input)
+		}
+	})
+	b.Run("pool", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = pool.EnsureJSON(input)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxTokenLen is the maximum length in bytes of the token reported by a ParseError
+const maxTokenLen = 32
+
+// locate fills the line, the column and the token of the *ParseError err holds, if any, from the
+// input it was found in, and returns err
+func locate(err error, input string) error {
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Line > 0 || perr.Offset < 0 || perr.Offset > len(input) {
+		return err
+	}
+
+	before := input[:perr.Offset]
+	perr.Line = strings.Count(before, "\n") + 1
+	perr.Column = utf8.RuneCountInString(before[strings.LastIndexByte(before, '\n')+1:]) + 1
+	// the token is cloned so that the error does not keep the input alive
+	perr.Token = strings.Clone(tokenAt(input[perr.Offset:]))
+
+	return err
+}
+
+// relocate moves the *ParseError values err holds, found in the text of input starting at offset,
+// to their positions in input, and returns err
+func relocate(err error, input string, offset int) error {
+	var perr *ParseError
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			relocate(inner, input, offset)
+		}
+	case *ParseError:
+		perr = e
+	case interface{ Unwrap() error }:
+		relocate(e.Unwrap(), input, offset)
+	}
+
+	if perr != nil && perr.Offset >= 0 {
+		perr.Offset += offset
+		perr.Line, perr.Column, perr.Token = 0, 0, ""
+		locate(perr, input)
+	}
+
+	return err
+}
+
+// tokenAt returns the text s starts with up to the next whitespace or delimiter, or the delimiter
+// s starts with, cut to maxTokenLen bytes
+func tokenAt(s string) string {
+	end := 0
+	for end < len(s) && end < maxTokenLen && strings.IndexByte(" \t\r\n,:[]{}", s[end]) < 0 {
+		end++
+	}
+	if end == 0 && len(s) > 0 {
+		end = 1
+	}
+	for end < len(s) && !utf8.RuneStart(s[end]) {
+		end--
+	}
+
+	return s[:end]
+}
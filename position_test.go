@@ -0,0 +1,58 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestParseErrorPosition(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *ParseError
+	}{
+		{
+			input:    "{\n  \"name\": \"张三\",\n  \"age\": thirty\n}",
+			expected: &ParseError{Offset: 31, Line: 3, Column: 10, Token: "thirty", Err: ErrUnexpectedToken},
+		},
+		{
+			input:    "{\n  \"a\" 1\n}",
+			expected: &ParseError{Offset: 8, Line: 2, Column: 7, Token: "1", Err: ErrUnexpectedToken},
+		},
+		{
+			input:    "[1, -e]",
+			expected: &ParseError{Offset: 4, Line: 1, Column: 5, Token: "-e", Err: ErrIncompleteNum},
+		},
+		{
+			input:    "",
+			expected: &ParseError{Offset: 0, Line: 1, Column: 1, Err: ErrUnexpectedToken},
+		},
+		{
+			input:    `[` + strings.Repeat("x", 40) + `]`,
+			expected: &ParseError{Offset: 1, Line: 1, Column: 2, Token: strings.Repeat("x", maxTokenLen), Err: ErrUnexpectedToken},
+		},
+	}
+
+	for _, test := range tests {
+		_, err := NewJSONParser(true).EnsureJSON(test.input)
+		require.Equal(t, test.expected, err, test.input)
+		require.True(t, errors.Is(err, test.expected.Err))
+	}
+
+	_, err := NewJSONParser(true).FastEnsureJSON("{\"a\":\n[1}")
+	require.Equal(t, &ParseError{Offset: 8, Line: 2, Column: 3, Token: "}", Err: ErrUnexpectedToken}, err)
+
+	// the error of the innermost container repaired on its own is located in the whole input
+	_, err = NewJSONParser(true).FastEnsureJSON("{\n  \"a\": 1,\n  \"b\": [1,\n    2 x")
+	require.Equal(t, &ParseError{Offset: 29, Line: 4, Column: 7, Token: "x", Err: ErrUnexpectedToken}, err)
+
+	require.Equal(t, "是", tokenAt("是" + strings.Repeat("的", 20))[:3])
+	require.LessOrEqual(t, len(tokenAt(strings.Repeat("的", 20))), maxTokenLen)
+}
@@ -0,0 +1,43 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import "strings"
+
+// pythonLiterals are the Python constants accepted by WithPythonLiterals
+var pythonLiterals = []literal{
+	{token: "True", value: true, replacement: "true"},
+	{token: "False", value: false, replacement: "false"},
+	{token: "None", value: nil, replacement: "null"},
+}
+
+// WithPythonLiterals accepts the Python constants True, False and None found outside strings as
+// true, false and null, as emitted by models printing Python dicts. A constant cut by the end of
+// the input is recorded as a truncated bool or null, its member or element being left out until
+// the constant is complete. Every replaced constant is reported as a repair
+func WithPythonLiterals() ParserOption {
+	return func(p *JSONParser) {
+		p.pythonLiterals = true
+	}
+}
+
+// parsePythonLiteral parses a Python constant accepted by WithPythonLiterals
+func (p *JSONParser) parsePythonLiteral(s string) (any, string, error) {
+	for _, lit := range pythonLiterals {
+		n := len(lit.token)
+		if strings.HasPrefix(s, lit.token) && (n == len(s) || !identByte(s[n])) {
+			p.repair(RepairReplacedLiteral, s, lit.token, lit.replacement)
+			return lit.value, s[n:], nil
+		}
+		if strings.HasPrefix(lit.token, s) {
+			p.truncate(s, kindOf(lit.value), nil)
+			return nil, "", errCutLiteral
+		}
+	}
+
+	return nil, s, ErrUnexpectedToken
+}
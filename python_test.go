@@ -0,0 +1,70 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPythonLiterals(t *testing.T) {
+	var repairs []Repair
+	parser := NewJSONParser(true, WithPythonLiterals(), WithOnRepair(func(r Repair) {
+		repairs = append(repairs, r)
+	}))
+
+	data, err := parser.EnsureJSON(`{"a":True,"b":[False,None,true],"c":"None"}`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":true,"b":[false,null,true],"c":"None"}`, data)
+	require.Equal(t, []Repair{
+		{Offset: 5, Original: "True", Replacement: "true", Kind: RepairReplacedLiteral},
+		{Offset: 15, Original: "False", Replacement: "false", Kind: RepairReplacedLiteral},
+		{Offset: 21, Original: "None", Replacement: "null", Kind: RepairReplacedLiteral},
+	}, repairs)
+
+	data, err = parser.FastEnsureJSON(`{"a":True,"b":[None`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":true,"b":[null]}`, data)
+
+	data, err = parser.EnsureJSON(`{"a":Nonesense}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+	require.Equal(t, `{}`, data)
+
+	value, status, err := parser.ParseWithStatus(`{"a":1,"b":Fal`)
+	require.Nil(t, err)
+	require.Equal(t, map[string]any{"a": float64(1)}, value)
+	require.Equal(t, KindBool, status.Kind)
+
+	tests := []struct {
+		input, expected string
+	}{
+		{input: `[True,Fa`, expected: `[true]`},
+		{input: `[Tr`, expected: `null`},
+		{input: `[1, Tr`, expected: `[1]`},
+		{input: `{"x":{"a":Tr`, expected: `{"x":{}}`},
+		{input: `{"x":[Tr`, expected: `{"x":null}`},
+		{input: `{"a":[1, Fal`, expected: `{"a":[1]}`},
+	}
+
+	for _, test := range tests {
+		data, err = parser.EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+	}
+
+	dec := NewStreamDecoder(parser)
+	snapshot, err := dec.Feed([]byte(`{"a":1,"b":No`))
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1}`, snapshot.JSON)
+	require.False(t, snapshot.Complete)
+	snapshot, err = dec.Feed([]byte(`ne}`))
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1,"b":null}`, snapshot.JSON)
+
+	_, err = NewJSONParser(true).EnsureJSON(`{"a":True}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
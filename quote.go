@@ -0,0 +1,67 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import "strconv"
+
+// WithAllowSingleQuotes accepts single-quoted strings and keys, as in {'name': 'Alice'}, which are
+// output double-quoted. Every converted string is reported as a repair. It is also enabled by WithJSON5
+func WithAllowSingleQuotes() ParserOption {
+	return func(p *JSONParser) {
+		p.singleQuotes = true
+	}
+}
+
+// parseSingleQuoted parses a single-quoted string such as 'Alice', which is output double-quoted
+func (p *JSONParser) parseSingleQuoted(s string) (any, string, error) {
+	end := singleQuoteEnd(s)
+	if end < 0 {
+		raw := s[1:]
+		if p.garbage == GarbageSkip {
+			raw = dropGarbage(raw)
+		}
+
+		// with its \' escapes decoded, the content is what a double-quoted string would hold
+		decoded := unescapeSingleQuote(raw)
+		p.truncate(s, KindString, partialString(decoded))
+		return p.incompleteStringValue(decoded, decoded)
+	}
+
+	result := unescapeLenient(unescapeSingleQuote(s[1:end]))
+	p.repair(RepairRequotedString, s, s[:end+1], strconv.Quote(result))
+	return result, s[end+1:], nil
+}
+
+// singleQuoteEnd returns the index of the quote closing the single-quoted string s starts with, -1 if it is not closed
+func singleQuoteEnd(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '\'':
+			return i
+		}
+	}
+
+	return -1
+}
+
+// unescapeSingleQuote decodes the \' escapes of the content of a single-quoted string, keeping the other escapes
+func unescapeSingleQuote(raw string) string {
+	buf := make([]byte, 0, len(raw))
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '\\' && i+1 < len(raw) {
+			i++
+			if raw[i] != '\'' {
+				buf = append(buf, '\\')
+			}
+		}
+		buf = append(buf, raw[i])
+	}
+
+	return string(buf)
+}
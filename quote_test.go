@@ -0,0 +1,57 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAllowSingleQuotes(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		strict          bool
+	}{
+		{input: `{'name': 'Alice', 'tags': ['a', "b"]}`, expected: `{"name":"Alice","tags":["a","b"]}`, strict: true},
+		{input: `{'quote': 'it\'s "ok"\n'}`, expected: `{"quote":"it's \"ok\"\n"}`, strict: true},
+		{input: `{"a": "it's"}`, expected: `{"a":"it's"}`, strict: true},
+		{input: `{'name': 'Ali`, expected: `{"name":null}`, strict: true},
+		{input: `{'name': 'Ali`, expected: `{"name":"Ali"}`},
+		{input: `{'quote': 'it\'s ok`, expected: `{"quote":"it's ok"}`},
+		{input: `{'name': 'Alice', 'ag`, expected: `{"name":"Alice"}`, strict: true},
+		{input: `{'name': 'Alice', 'ag`, expected: `{"name":"Alice"}`},
+	}
+
+	for _, test := range tests {
+		parser := NewJSONParser(test.strict, WithAllowSingleQuotes())
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+
+		data, err = parser.FastEnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+	}
+
+	data, err := NewJSONParser(false, WithAllowSingleQuotes(), WithIncompleteKeyPrefix()).EnsureJSON(`{'name': 'Alice', 'it\'s`)
+	require.Nil(t, err)
+	require.Equal(t, `{"it's":null,"name":"Alice"}`, data)
+
+	var repairs []Repair
+	parser := NewJSONParser(true, WithAllowSingleQuotes(), WithOnRepair(func(r Repair) {
+		repairs = append(repairs, r)
+	}))
+	var obj struct {
+		Name string `json:"name"`
+	}
+	require.Nil(t, parser.Unmarshal([]byte(`{'name': 'Alice'}`), &obj))
+	require.Equal(t, "Alice", obj.Name)
+	require.Equal(t, []Repair{
+		{Offset: 1, Original: "'name'", Replacement: `"name"`, Kind: RepairRequotedString},
+		{Offset: 9, Original: "'Alice'", Replacement: `"Alice"`, Kind: RepairRequotedString},
+	}, repairs)
+}
@@ -0,0 +1,67 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Records is a stream of concatenated or newline-delimited JSON documents split into records
+type Records struct {
+	// Complete holds the repaired JSON of the complete records, in input order
+	Complete []string
+	// Partial is the repaired JSON of the record the input ended in, "" if it ended between records
+	Partial string
+}
+
+// RecordError describes a record of a stream that could not be repaired
+type RecordError struct {
+	// Index is the 0-based index of the record in the stream
+	Index int
+	// Offset is the byte offset of the record in the stream
+	Offset int
+	// Err is the error returned by the repair of the record, its offsets being offsets of the record
+	Err error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("record %d at offset %d: %v", e.Index, e.Offset, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// EnsureJSONRecords splits s, a stream of JSON documents either concatenated or delimited with
+// newlines as in NDJSON and JSON Lines, into records, and repairs them. Only the record the input
+// ended in is completed, the others being complete already. Each record must be an object or an
+// array. When a record can not be repaired, the records before it are returned alongside a *RecordError
+func (p *JSONParser) EnsureJSONRecords(s string) (Records, error) {
+	var recs Records
+	offset := 0
+	for {
+		rest := strings.TrimLeft(s[offset:], " \t\r\n")
+		offset = len(s) - len(rest)
+		if rest == "" {
+			return recs, nil
+		}
+
+		end, complete := valueEnd(rest)
+		data, err := p.EnsureJSON(rest[:end])
+		if err != nil {
+			return recs, &RecordError{Index: len(recs.Complete), Offset: offset, Err: err}
+		}
+		if !complete {
+			recs.Partial = data
+			return recs, nil
+		}
+
+		recs.Complete = append(recs.Complete, data)
+		offset += end
+	}
+}
@@ -0,0 +1,49 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestEnsureJSONRecords(t *testing.T) {
+	parser := NewJSONParser(false)
+
+	tests := []struct {
+		input    string
+		expected Records
+	}{
+		{
+			input: "{\"a\":1}\n{\"a\": \"}\"}\r\n[1, 2]{\"b\": [3, {\"c\": \"x",
+			expected: Records{
+				Complete: []string{`{"a":1}`, `{"a":"}"}`, `[1,2]`},
+				Partial:  `{"b":[3,{"c":"x"}]}`,
+			},
+		},
+		{
+			input:    "{\"a\":1}\n{\"a\":2}\n",
+			expected: Records{Complete: []string{`{"a":1}`, `{"a":2}`}},
+		},
+		{
+			input:    "",
+			expected: Records{},
+		},
+	}
+
+	for _, test := range tests {
+		recs, err := parser.EnsureJSONRecords(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, recs, test.input)
+	}
+
+	recs, err := parser.EnsureJSONRecords("{\"a\":1}\nnot json\n{\"a\":2}")
+	require.Equal(t, Records{Complete: []string{`{"a":1}`}}, recs)
+	require.Equal(t, &RecordError{Index: 1, Offset: 8, Err: &ParseError{Offset: 0, Line: 1, Column: 1, Token: "not", Err: ErrUnexpectedToken}}, err)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+	require.EqualError(t, err, "record 1 at offset 8: unexpected token at offset 0 (line 1, column 1)")
+}
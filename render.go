@@ -0,0 +1,110 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import "sort"
+
+// RenderNode is a node of a display tree built from partial JSON
+type RenderNode struct {
+	// Kind is the kind of the value, KindUnknown if it has not started yet
+	Kind Kind
+	// Key is the member name when the parent is an object
+	Key string
+	// Index is the element index when the parent is an array
+	Index int
+	// Value is the value of a scalar, or the streamed prefix of a pending string
+	Value any
+	// Children are the members of an object sorted by key, or the elements of an array
+	Children []*RenderNode
+	// Pending reports whether the value is still being streamed
+	Pending bool
+}
+
+// RenderTree parses partial JSON into a display tree in which the values still being streamed
+// are marked pending, so that UI layers can render them without deriving completeness themselves
+func (p *JSONParser) RenderTree(s string) (*RenderNode, error) {
+	sp := p.session(s)
+	data, err := sp.run()
+	if err != nil {
+		return nil, err
+	}
+
+	root := newRenderNode(data)
+	if tr := sp.state.truncation; tr != nil {
+		node := root
+		node.Pending = true
+		for _, seg := range tr.path {
+			node = node.child(seg)
+			node.Pending = true
+		}
+
+		node.Kind = tr.kind
+		if tr.partial != nil {
+			node.Value = tr.partial
+		}
+	}
+
+	return root, nil
+}
+
+func newRenderNode(v any) *RenderNode {
+	node := &RenderNode{Kind: kindOf(v)}
+	switch val := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			child := newRenderNode(val[k])
+			child.Key = k
+			node.Children = append(node.Children, child)
+		}
+	case []any:
+		for i, e := range val {
+			child := newRenderNode(e)
+			child.Index = i
+			node.Children = append(node.Children, child)
+		}
+	default:
+		node.Value = v
+	}
+
+	return node
+}
+
+// child returns the member or element seg of the node, creating it if it is missing
+func (n *RenderNode) child(seg any) *RenderNode {
+	switch seg := seg.(type) {
+	case int:
+		n.Kind = KindArray
+		for _, c := range n.Children {
+			if c.Index == seg {
+				return c
+			}
+		}
+
+		c := &RenderNode{Index: seg}
+		n.Children = append(n.Children, c)
+		return c
+	case string:
+		n.Kind = KindObject
+		for _, c := range n.Children {
+			if c.Key == seg {
+				return c
+			}
+		}
+
+		c := &RenderNode{Key: seg}
+		n.Children = append(n.Children, c)
+		return c
+	}
+
+	return n
+}
@@ -0,0 +1,79 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRenderTree(t *testing.T) {
+	tests := []struct {
+		input    string
+		strict   bool
+		expected *RenderNode
+	}{
+		{
+			input:  `{"question":"你好","options":["A","是\"初`,
+			strict: true,
+			expected: &RenderNode{Kind: KindObject, Pending: true, Children: []*RenderNode{
+				{Kind: KindArray, Key: "options", Pending: true, Children: []*RenderNode{
+					{Kind: KindString, Value: "A"},
+					{Kind: KindString, Index: 1, Value: "是\"初", Pending: true},
+				}},
+				{Kind: KindString, Key: "question", Value: "你好"},
+			}},
+		},
+		{
+			input:  `{"name":"Ali\u4f`,
+			strict: false,
+			expected: &RenderNode{Kind: KindObject, Pending: true, Children: []*RenderNode{
+				{Kind: KindString, Key: "name", Value: "Ali", Pending: true},
+			}},
+		},
+		{
+			input:  `{"count":12`,
+			strict: true,
+			expected: &RenderNode{Kind: KindObject, Pending: true, Children: []*RenderNode{
+				{Kind: KindNumber, Key: "count", Value: float64(12), Pending: true},
+			}},
+		},
+		{
+			input:  `{"done":true,"roles":[{`,
+			strict: true,
+			expected: &RenderNode{Kind: KindObject, Pending: true, Children: []*RenderNode{
+				{Kind: KindBool, Key: "done", Value: true},
+				{Kind: KindArray, Key: "roles", Pending: true, Children: []*RenderNode{
+					{Kind: KindObject, Pending: true},
+				}},
+			}},
+		},
+		{
+			input:  `{"a":null,"b":`,
+			strict: true,
+			expected: &RenderNode{Kind: KindObject, Pending: true, Children: []*RenderNode{
+				{Kind: KindNull, Key: "a"},
+				{Kind: KindUnknown, Key: "b", Pending: true},
+			}},
+		},
+		{
+			input:  `[1,2]`,
+			strict: true,
+			expected: &RenderNode{Kind: KindArray, Children: []*RenderNode{
+				{Kind: KindNumber, Value: float64(1)},
+				{Kind: KindNumber, Index: 1, Value: float64(2)},
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		parser := NewJSONParser(test.strict)
+		tree, err := parser.RenderTree(test.input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, tree, test.input)
+	}
+}
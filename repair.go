@@ -0,0 +1,152 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+// RepairKind is the category of a repair
+type RepairKind int
+
+const (
+	// RepairUnknown is the kind of a Repair whose kind is not set
+	RepairUnknown RepairKind = iota
+	// RepairStrippedJSONP is the removal of a JSONP callback wrapper such as cb( and );
+	RepairStrippedJSONP
+	// RepairQuotedKey is the quoting of a key missing its opening quote or both its quotes
+	RepairQuotedKey
+	// RepairDroppedQuote is the removal of a stray quote
+	RepairDroppedQuote
+	// RepairNormalizedEscape is the decoding of nonstandard escapes or raw control characters in a string
+	RepairNormalizedEscape
+	// RepairNormalizedNumber is the normalization of a number with a bare leading or trailing decimal point
+	// or a leading plus sign
+	RepairNormalizedNumber
+	// RepairRadixNumber is the conversion of a hexadecimal, octal or binary literal to decimal
+	RepairRadixNumber
+	// RepairFullwidthDigits is the conversion of a number written with fullwidth characters to ASCII
+	RepairFullwidthDigits
+	// RepairDecimalComma is the conversion of a number written with a decimal comma
+	RepairDecimalComma
+	// RepairDroppedGarbage is the removal of binary garbage
+	RepairDroppedGarbage
+	// RepairDroppedInvisible is the removal of invisible characters
+	RepairDroppedInvisible
+	// RepairDroppedTag is the removal of an HTML or XML tag
+	RepairDroppedTag
+	// RepairStrippedMarkdown is the removal of the markdown wrappers of a string value
+	RepairStrippedMarkdown
+	// RepairReplacedLiteral is the replacement of a literal registered with WithLiterals or of a Python constant
+	RepairReplacedLiteral
+	// RepairStrippedFence is the removal of the markdown code fence and the prose around the document
+	RepairStrippedFence
+	// RepairDroppedComment is the removal of a // or /* */ comment
+	RepairDroppedComment
+	// RepairRequotedString is the conversion of a single-quoted string to a double-quoted one
+	RepairRequotedString
+	// RepairNonFiniteNumber is the replacement of NaN, Infinity or -Infinity
+	RepairNonFiniteNumber
+	// RepairInsertedComma is the insertion of the comma missing between two members or elements
+	RepairInsertedComma
+	// RepairClosedString is the closing of a string value cut by the end of the input
+	RepairClosedString
+	// RepairClosedBracket is the closing of an object or an array cut by the end of the input
+	RepairClosedBracket
+	// RepairDroppedTrailingMember is the removal of a member or an element cut by the end of the
+	// input, or of the value of a member replaced with null
+	RepairDroppedTrailingMember
+	// RepairDroppedTrailingComma is the removal of a comma ending the members or elements of a container
+	RepairDroppedTrailingComma
+)
+
+var repairKindNames = [...]string{
+	RepairUnknown:          "unknown",
+	RepairStrippedJSONP:    "stripped jsonp",
+	RepairQuotedKey:        "quoted key",
+	RepairDroppedQuote:     "dropped quote",
+	RepairNormalizedEscape: "normalized escape",
+	RepairNormalizedNumber: "normalized number",
+	RepairRadixNumber:      "radix number",
+	RepairFullwidthDigits:  "fullwidth digits",
+	RepairDecimalComma:     "decimal comma",
+	RepairDroppedGarbage:   "dropped garbage",
+	RepairDroppedInvisible: "dropped invisible",
+	RepairDroppedTag:       "dropped tag",
+	RepairStrippedMarkdown: "stripped markdown",
+	RepairReplacedLiteral:  "replaced literal",
+	RepairStrippedFence:    "stripped fence",
+	RepairDroppedComment:   "dropped comment",
+	RepairRequotedString:   "requoted string",
+	RepairNonFiniteNumber:  "non-finite number",
+	RepairInsertedComma:    "inserted comma",
+	RepairClosedString:     "closed string",
+	RepairClosedBracket:    "closed bracket",
+
+	RepairDroppedTrailingMember: "dropped trailing member",
+	RepairDroppedTrailingComma:  "dropped trailing comma",
+}
+
+// String returns the name of the repair kind
+func (k RepairKind) String() string {
+	if k < 0 || int(k) >= len(repairKindNames) {
+		return "unknown"
+	}
+
+	return repairKindNames[k]
+}
+
+// completes reports whether the repair completes a truncated input rather than normalizing its syntax
+func (k RepairKind) completes() bool {
+	return k == RepairClosedString || k == RepairClosedBracket || k == RepairDroppedTrailingMember
+}
+
+// normalized returns the repairs of the session that normalize the syntax of the input, leaving out
+// the completion of a truncated input
+func (st *parseState) normalized() []Repair {
+	var repairs []Repair
+	for _, r := range st.repairs {
+		if !r.Kind.completes() {
+			repairs = append(repairs, r)
+		}
+	}
+	return repairs
+}
+
+// Repair describes a piece of the input that was normalized or completed to produce valid JSON.
+// Completing a truncated input, such as closing its strings and containers, is reported with the
+// kinds RepairClosedString, RepairClosedBracket and RepairDroppedTrailingMember, at the end of the
+// input or at the start of the dropped member
+type Repair struct {
+	// Offset is the byte offset of the repaired text in the input
+	Offset int
+	// Original is the text found in the input
+	Original string
+	// Replacement is the text used in the output
+	Replacement string
+	// Kind is the category of the repair
+	Kind RepairKind
+}
+
+// WithOnRepair sets a function called for every repair applied while parsing
+func WithOnRepair(fn func(r Repair)) ParserOption {
+	return func(p *JSONParser) {
+		p.onRepair = fn
+	}
+}
+
+// repair records the repair of the given kind replacing the text original found at the start
+// of the remaining text s with replacement
+func (p *JSONParser) repair(kind RepairKind, s, original, replacement string) {
+	r := Repair{
+		Offset:      p.offset(s),
+		Original:    original,
+		Replacement: replacement,
+		Kind:        kind,
+	}
+
+	p.state.repairs = append(p.state.repairs, r)
+	if p.onRepair != nil {
+		p.onRepair(r)
+	}
+}
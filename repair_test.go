@@ -0,0 +1,220 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestOnRepair(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		repairs         []Repair
+	}{
+		{
+			input:    `{"a":.5,"b":5.,"c":-.25`,
+			expected: `{"a":0.5,"b":5,"c":-0.25}`,
+			repairs: []Repair{
+				{Offset: 5, Original: ".5", Replacement: "0.5", Kind: RepairNormalizedNumber},
+				{Offset: 12, Original: "5.", Replacement: "5", Kind: RepairNormalizedNumber},
+				{Offset: 19, Original: "-.25", Replacement: "-0.25", Kind: RepairNormalizedNumber},
+				{Offset: 23, Original: "", Replacement: "}", Kind: RepairClosedBracket},
+			},
+		},
+		{
+			input:    `[0x1F, 1.5, 2]`,
+			expected: `[31,1.5,2]`,
+			repairs: []Repair{
+				{Offset: 1, Original: "0x1F", Replacement: "31", Kind: RepairRadixNumber},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var repairs []Repair
+		parser := NewJSONParser(true, WithLenient(), WithOnRepair(func(r Repair) {
+			repairs = append(repairs, r)
+		}))
+
+		data, err := parser.FastEnsureJSON(test.input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+		require.Equal(t, test.repairs, repairs)
+	}
+
+	var repairs []Repair
+	parser := NewJSONParser(true, WithOnRepair(func(r Repair) {
+		repairs = append(repairs, r)
+	}))
+	_, err := parser.EnsureJSON(`{"a":.5}`)
+	require.ErrorIs(t, err, ErrIncompleteNum)
+	_, err = parser.EnsureJSON(`{"a":5.,"b":1}`)
+	require.ErrorIs(t, err, ErrIncompleteNum)
+
+	data, err := parser.EnsureJSON(`{"a":5.`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":5}`, data)
+	require.Equal(t, []Repair{{Offset: 7, Original: "", Replacement: "}", Kind: RepairClosedBracket}}, repairs)
+}
+
+func TestStrayQuotes(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		repairs         []Repair
+	}{
+		{
+			input:    `{"a":""hello","b":"", " "c":1}`,
+			expected: `{"a":"hello","b":"","c":1}`,
+			repairs: []Repair{
+				{Offset: 5, Original: `"`, Replacement: "", Kind: RepairDroppedQuote},
+				{Offset: 22, Original: `" `, Replacement: "", Kind: RepairDroppedQuote},
+			},
+		},
+		{
+			input:    `["x"", 1" ,"y"`,
+			expected: `["x",1,"y"]`,
+			repairs: []Repair{
+				{Offset: 4, Original: `"`, Replacement: "", Kind: RepairDroppedQuote},
+				{Offset: 8, Original: `"`, Replacement: "", Kind: RepairDroppedQuote},
+				{Offset: 14, Original: "", Replacement: "]", Kind: RepairClosedBracket},
+			},
+		},
+		{
+			input:    `{"a":""wor`,
+			expected: `{"a":"wor"}`,
+			repairs: []Repair{
+				{Offset: 5, Original: `"`, Replacement: "", Kind: RepairDroppedQuote},
+				{Offset: 10, Original: "", Replacement: `"`, Kind: RepairClosedString},
+				{Offset: 10, Original: "", Replacement: "}", Kind: RepairClosedBracket},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var repairs []Repair
+		parser := NewJSONParser(false, WithLenient(), WithOnRepair(func(r Repair) {
+			repairs = append(repairs, r)
+		}))
+
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+		require.Equal(t, test.repairs, repairs)
+	}
+
+	_, err := NewJSONParser(false).EnsureJSON(`{"a":""hello"}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
+
+func TestUnquotedKeys(t *testing.T) {
+	var repairs []Repair
+	parser := NewJSONParser(true, WithLenient(), WithOnRepair(func(r Repair) {
+		repairs = append(repairs, r)
+	}))
+
+	data, err := parser.EnsureJSON(`{name": "Alice", "age":30, 角色_1" :{role":"DJ"}, note"`)
+	require.Nil(t, err)
+	require.Equal(t, `{"age":30,"name":"Alice","note":null,"角色_1":{"role":"DJ"}}`, data)
+	require.Equal(t, []Repair{
+		{Offset: 1, Original: `name"`, Replacement: `"name"`, Kind: RepairQuotedKey},
+		{Offset: 27, Original: `角色_1"`, Replacement: `"角色_1"`, Kind: RepairQuotedKey},
+		{Offset: 39, Original: `role"`, Replacement: `"role"`, Kind: RepairQuotedKey},
+		{Offset: 52, Original: `note"`, Replacement: `"note"`, Kind: RepairQuotedKey},
+		{Offset: 57, Original: "", Replacement: "null", Kind: RepairDroppedTrailingMember},
+		{Offset: 57, Original: "", Replacement: "}", Kind: RepairClosedBracket},
+	}, repairs)
+
+	_, err = NewJSONParser(true).EnsureJSON(`{name": "Alice"}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
+
+func TestUnicodeWhitespace(t *testing.T) {
+	input := "　{　\"question\"　: \"如何面对？\", \"options\":[　1　, 2]　}　\n"
+
+	var extra string
+	data, err := NewJSONParser(true, WithLenient(), WithOnExtraToken(func(_ string, _ any, remaining string) {
+		extra = remaining
+	})).EnsureJSON(input)
+	require.Nil(t, err)
+	require.Equal(t, `{"options":[1,2],"question":"如何面对？"}`, data)
+	require.Equal(t, "", extra)
+
+	_, err = NewJSONParser(true).EnsureJSON(input)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
+
+func TestCompletionRepairs(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		strict          bool
+		repairs         []Repair
+	}{
+		{
+			input:    `[1 2 {"a":1 "b":2}]`,
+			expected: `[1,2,{"a":1,"b":2}]`,
+			strict:   true,
+			repairs: []Repair{
+				{Offset: 3, Original: "", Replacement: ",", Kind: RepairInsertedComma},
+				{Offset: 5, Original: "", Replacement: ",", Kind: RepairInsertedComma},
+				{Offset: 12, Original: "", Replacement: ",", Kind: RepairInsertedComma},
+			},
+		},
+		{
+			input:    `[{"a":1,"ke`,
+			expected: `[{"a":1}]`,
+			repairs: []Repair{
+				{Offset: 8, Original: `"ke`, Replacement: "", Kind: RepairDroppedTrailingMember},
+				{Offset: 11, Original: "", Replacement: "}", Kind: RepairClosedBracket},
+				{Offset: 11, Original: "", Replacement: "]", Kind: RepairClosedBracket},
+			},
+		},
+		{
+			input:    `{"a":[1,"x`,
+			expected: `{"a":[1]}`,
+			strict:   true,
+			repairs: []Repair{
+				{Offset: 8, Original: `"x`, Replacement: "", Kind: RepairDroppedTrailingMember},
+				{Offset: 10, Original: "", Replacement: "]", Kind: RepairClosedBracket},
+				{Offset: 10, Original: "", Replacement: "}", Kind: RepairClosedBracket},
+			},
+		},
+		{
+			input:    `{"a":[1,"x`,
+			expected: `{"a":[1,"x"]}`,
+			repairs: []Repair{
+				{Offset: 10, Original: "", Replacement: `"`, Kind: RepairClosedString},
+				{Offset: 10, Original: "", Replacement: "]", Kind: RepairClosedBracket},
+				{Offset: 10, Original: "", Replacement: "}", Kind: RepairClosedBracket},
+			},
+		},
+		{
+			input:    `{"a":"x`,
+			expected: `{"a":null}`,
+			strict:   true,
+			repairs: []Repair{
+				{Offset: 5, Original: `"x`, Replacement: "null", Kind: RepairDroppedTrailingMember},
+				{Offset: 7, Original: "", Replacement: "}", Kind: RepairClosedBracket},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var repairs []Repair
+		parser := NewJSONParser(test.strict, WithOnRepair(func(r Repair) {
+			repairs = append(repairs, r)
+		}))
+
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data, test.input)
+		require.Equal(t, test.repairs, repairs, test.input)
+	}
+
+	require.Equal(t, RepairUnknown, Repair{}.Kind)
+	require.Equal(t, "unknown", Repair{}.Kind.String())
+}
@@ -0,0 +1,123 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// Router dispatches the top-level members of a document streamed into a StreamDecoder to the
+// handlers registered for them as their values complete. Every handler runs in its own goroutine
+// and buffers its values independently, so that the components consuming a large mixed document
+// work concurrently without blocking the decoder nor each other
+type Router struct {
+	dec  *StreamDecoder
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewRouter creates a Router dispatching the members of the document streamed into dec
+func NewRouter(dec *StreamDecoder) *Router {
+	return &Router{dec: dec}
+}
+
+// Handle registers fn to be called with the value of the top-level member key once it is complete.
+// Like Watch, Handle must not be called concurrently with Feed
+func (r *Router) Handle(key string, fn func(value json.RawMessage) error) {
+	segs := []string{key}
+	r.route(fn, func(snapshot Snapshot, tr *truncation, push func(json.RawMessage)) (bool, error) {
+		v, ok := lookupPath(snapshot.Data, segs)
+		if !ok {
+			return true, nil
+		}
+		if tr != nil && pathHasPrefix(tr.path, segs) {
+			return true, nil
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			return false, err
+		}
+		push(b)
+		return false, nil
+	})
+}
+
+// HandleElements registers fn to be called with every element of the array held by the top-level
+// member key as soon as the element is complete, index being its position in the array
+func (r *Router) HandleElements(key string, fn func(index int, elem json.RawMessage) error) {
+	segs := []string{key}
+	sent, handled := 0, 0
+	r.route(func(elem json.RawMessage) error {
+		handled++
+		return fn(handled-1, elem)
+	}, func(snapshot Snapshot, tr *truncation, push func(json.RawMessage)) (bool, error) {
+		v, ok := lookupPath(snapshot.Data, segs)
+		arr, isArray := v.([]any)
+		if !ok || !isArray {
+			return true, nil
+		}
+
+		for ; sent < len(arr); sent++ {
+			if tr != nil && pathHasPrefix(tr.path, []string{key, strconv.Itoa(sent)}) {
+				break
+			}
+
+			b, err := json.Marshal(arr[sent])
+			if err != nil {
+				return false, err
+			}
+			push(b)
+		}
+
+		return tr != nil && pathHasPrefix(tr.path, segs), nil
+	})
+}
+
+// Wait waits for the handlers to process the values of the document, which returns once the
+// document is complete or the decoder is closed, and returns the errors they returned joined
+func (r *Router) Wait() error {
+	r.wg.Wait()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return errors.Join(r.errs...)
+}
+
+// route registers a watcher of the decoder pushing values with notify to a goroutine calling fn.
+// Once fn fails, the values left are discarded
+func (r *Router) route(fn func(json.RawMessage) error,
+	notify func(snapshot Snapshot, tr *truncation, push func(json.RawMessage)) (bool, error)) {
+	mb := newMailbox[json.RawMessage]()
+	r.dec.watchers = append(r.dec.watchers, &watcher{
+		notify: func(snapshot Snapshot, tr *truncation) (bool, error) {
+			if mb.stopped() {
+				return false, nil
+			}
+
+			return notify(snapshot, tr, mb.push)
+		},
+		close: mb.close,
+	})
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		for v := range mb.out {
+			if err := fn(v); err != nil {
+				r.mu.Lock()
+				r.errs = append(r.errs, err)
+				r.mu.Unlock()
+				mb.stop()
+			}
+		}
+	}()
+}
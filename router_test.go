@@ -0,0 +1,47 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRouter(t *testing.T) {
+	input := `{"roles":[{"role_name":"我"},{"role_name":"墨镜僵尸"}],"scene_list":[{"scene":"夜晚"},{"scene":"白天"},{"scene":"黄昏"}],"question":"如何面对？"}`
+
+	dec := NewStreamDecoder(NewJSONParser(true))
+	router := NewRouter(dec)
+
+	var roles json.RawMessage
+	router.Handle("roles", func(value json.RawMessage) error {
+		roles = value
+		return nil
+	})
+	var scenes []string
+	var indexes []int
+	router.HandleElements("scene_list", func(index int, elem json.RawMessage) error {
+		indexes = append(indexes, index)
+		scenes = append(scenes, string(elem))
+		return nil
+	})
+	errMissing := errors.New("missing")
+	router.Handle("missing", func(json.RawMessage) error { return errMissing })
+	router.Handle("question", func(json.RawMessage) error { return errMissing })
+
+	for i := 0; i < len(input); i += 9 {
+		_, err := dec.Feed([]byte(input[i:min(i+9, len(input))]))
+		require.Nil(t, err)
+	}
+
+	require.ErrorIs(t, router.Wait(), errMissing)
+	require.JSONEq(t, `[{"role_name":"我"},{"role_name":"墨镜僵尸"}]`, string(roles))
+	require.Equal(t, []int{0, 1, 2}, indexes)
+	require.Equal(t, []string{`{"scene":"夜晚"}`, `{"scene":"白天"}`, `{"scene":"黄昏"}`}, scenes)
+}
@@ -0,0 +1,77 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+// arraySample limits the number of elements kept from the arrays at a path
+type arraySample struct {
+	segs []segment
+	all  bool
+	n    int
+}
+
+// WithArraySample keeps only the first n elements of every array, the other elements being
+// skipped without being decoded. It is meant for quick previews of enormous documents
+func WithArraySample(n int) ParserOption {
+	return func(p *JSONParser) {
+		p.samples = append(p.samples, arraySample{all: true, n: n})
+	}
+}
+
+// WithArraySampleAt keeps only the first n elements of the array at path, overriding WithArraySample.
+// In the path, a # segment matches any member or element, \# standing for a member named #
+func WithArraySampleAt(path string, n int) ParserOption {
+	return func(p *JSONParser) {
+		p.samples = append(p.samples, arraySample{segs: parsePattern(path), n: n})
+	}
+}
+
+// WithOnSampled sets a function called for every sampled array with the number of elements skipped
+func WithOnSampled(fn func(path string, skipped int)) ParserOption {
+	return func(p *JSONParser) {
+		p.onSampled = fn
+	}
+}
+
+// sampleLimit returns the number of elements kept from the array at the current path
+func (p *JSONParser) sampleLimit() (int, bool) {
+	n, found := 0, false
+	for _, sample := range p.samples {
+		if sample.all && !found {
+			n, found = sample.n, true
+		}
+	}
+	for _, sample := range p.samples {
+		if !sample.all && matchPath(p.state.path, sample.segs) {
+			return sample.n, true
+		}
+	}
+
+	return n, found
+}
+
+// skipElements skips the elements of an array up to its end, returning the remaining
+// text, the number of elements skipped and whether the array was closed
+func (p *JSONParser) skipElements(s string) (string, int, bool) {
+	skipped := 0
+	for len(s) > 0 {
+		if s[0] == ']' {
+			return s[1:], skipped, true
+		}
+
+		skipped++
+		rest := skipMember(s, ']')
+		if len(rest) == 0 {
+			return "", skipped, false
+		}
+		if rest[0] == ',' {
+			rest = rest[1:]
+		}
+		s = p.trimSpace(rest)
+	}
+
+	return s, skipped, false
+}
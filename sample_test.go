@@ -0,0 +1,50 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestArraySample(t *testing.T) {
+	type sampled struct {
+		path    string
+		skipped int
+	}
+
+	tests := []struct {
+		input, expected string
+		opts            []ParserOption
+		sampled         []sampled
+	}{
+		{
+			input:    `{"a":[1,2,3,4],"b":[[1,2],["x]",{"y":[5]}],[6]],"c":[]}`,
+			expected: `{"a":[1,2],"b":[[1,2],["x]",{"y":[5]}]],"c":null}`,
+			opts:     []ParserOption{WithArraySample(2)},
+			sampled:  []sampled{{"a", 2}, {"b", 1}},
+		},
+		{
+			input:    `{"roles":[{"tags":[1,2,3]},{"tags":[4,5]},{"tags":[6`,
+			expected: `{"roles":[{"tags":[1]},{"tags":[4]}]}`,
+			opts:     []ParserOption{WithArraySample(2), WithArraySampleAt("roles.#.tags", 1)},
+			sampled:  []sampled{{"roles.0.tags", 2}, {"roles.1.tags", 1}, {"roles", 1}},
+		},
+	}
+
+	for _, test := range tests {
+		var events []sampled
+		opts := append(test.opts, WithOnSampled(func(path string, skipped int) {
+			events = append(events, sampled{path, skipped})
+		}))
+
+		data, err := NewJSONParser(true, opts...).FastEnsureJSON(test.input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+		require.Equal(t, test.sampled, events)
+	}
+}
@@ -0,0 +1,33 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import "strings"
+
+// WithScalarValues accepts a top-level string, number, boolean or null, as in "Paris" or 42, which
+// the parser otherwise rejects with ErrUnexpectedToken as it expects an object or an array. Some
+// models, such as OpenAI ones calling functions, occasionally answer with a bare string.
+// A truncated string is completed by non-strict parsers, strict ones returning ErrIncompleteString
+// as there is no member to drop it from
+func WithScalarValues() ParserOption {
+	return func(p *JSONParser) {
+		p.scalars = true
+	}
+}
+
+// startsDocument reports whether s starts a top-level value the parser accepts
+func (p *JSONParser) startsDocument(s string) bool {
+	if strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[") {
+		return true
+	}
+	if !p.scalars || s == "" || strings.ContainsRune(" \t\r\n", rune(s[0])) {
+		return false
+	}
+
+	_, ok := p.parsers[rune(s[0])]
+	return ok
+}
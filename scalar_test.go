@@ -0,0 +1,55 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestScalarValues(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		strict          bool
+	}{
+		{input: `"Paris"`, expected: `"Paris"`, strict: true},
+		{input: `42`, expected: `42`, strict: true},
+		{input: `-1.5e3`, expected: `-1500`, strict: true},
+		{input: `true`, expected: `true`, strict: true},
+		{input: `null`, expected: `null`, strict: true},
+		{input: `"Paris" is the answer`, expected: `"Paris"`, strict: true},
+		{input: `{"a": 1`, expected: `{"a":1}`, strict: true},
+		{input: `"Par`, expected: `"Par"`},
+	}
+
+	for _, test := range tests {
+		parser := NewJSONParser(test.strict, WithScalarValues())
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+
+		data, err = parser.FastEnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+	}
+
+	_, err := NewJSONParser(true, WithScalarValues()).EnsureJSON(`"Par`)
+	require.ErrorIs(t, err, ErrIncompleteString)
+
+	_, err = NewJSONParser(false, WithScalarValues()).EnsureJSON(`-`)
+	require.ErrorIs(t, err, ErrIncompleteNum)
+
+	_, err = NewJSONParser(false, WithScalarValues()).EnsureJSON(`Paris`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+
+	_, err = NewJSONParser(false).EnsureJSON(`"Paris"`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+
+	var answer string
+	require.Nil(t, NewJSONParser(false, WithScalarValues()).Unmarshal([]byte(`"Paris, Fra`), &answer))
+	require.Equal(t, "Paris, Fra", answer)
+}
@@ -0,0 +1,169 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Schema is a JSON Schema describing the documents it was inferred from
+type Schema struct {
+	// Type holds the JSON Schema types observed, sorted
+	Type []string
+	// Properties holds the schemas of the members observed in objects
+	Properties map[string]*Schema
+	// Required holds the members found in every complete object, sorted
+	Required []string
+	// Items holds the schema of the elements observed in arrays
+	Items *Schema
+
+	// objects reports whether a complete object was observed, Required being known only then
+	objects bool
+}
+
+// MarshalJSON encodes the schema as a JSON Schema document
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	var typ any = s.Type
+	if len(s.Type) == 1 {
+		typ = s.Type[0]
+	}
+
+	return json.Marshal(struct {
+		Type       any                `json:"type,omitempty"`
+		Properties map[string]*Schema `json:"properties,omitempty"`
+		Required   []string           `json:"required,omitempty"`
+		Items      *Schema            `json:"items,omitempty"`
+	}{typ, s.Properties, s.Required, s.Items})
+}
+
+// InferSchema infers a JSON Schema from one or more partial documents, merging the members and
+// types observed. Members still being streamed are described by their streamed part, and the
+// members of objects still being streamed are not used to decide which members are required
+func (p *JSONParser) InferSchema(docs ...string) (*Schema, error) {
+	var schema *Schema
+	for _, doc := range docs {
+		sp := p.session(doc)
+		data, err := sp.run()
+		if err != nil {
+			return nil, err
+		}
+
+		schema = schema.merge(inferSchema(data, nil, sp.state.truncation))
+	}
+
+	return schema, nil
+}
+
+// inferSchema infers the schema of the value v found at path
+func inferSchema(v any, path []any, tr *truncation) *Schema {
+	switch v := v.(type) {
+	case nil:
+		return &Schema{Type: []string{"null"}}
+	case bool:
+		return &Schema{Type: []string{"boolean"}}
+	case float64:
+		if v == math.Trunc(v) {
+			return &Schema{Type: []string{"integer"}}
+		}
+		return &Schema{Type: []string{"number"}}
+	case json.Number:
+		if strings.ContainsAny(string(v), ".eE") {
+			return &Schema{Type: []string{"number"}}
+		}
+		return &Schema{Type: []string{"integer"}}
+	case *big.Int:
+		return &Schema{Type: []string{"integer"}}
+	case string:
+		return &Schema{Type: []string{"string"}}
+	case []any:
+		schema := &Schema{Type: []string{"array"}}
+		for i, elem := range v {
+			schema.Items = schema.Items.merge(inferSchema(elem, append(path[:len(path):len(path)], i), tr))
+		}
+		return schema
+	case map[string]any:
+		schema := &Schema{Type: []string{"object"}, Properties: make(map[string]*Schema), objects: !tr.covers(path)}
+		for key, val := range v {
+			child := append(path[:len(path):len(path)], key)
+			if tr != nil && tr.kind == KindUnknown && len(tr.path) == len(child) && tr.covers(child) {
+				continue
+			}
+
+			schema.Properties[key] = inferSchema(val, child, tr)
+			if schema.objects {
+				schema.Required = append(schema.Required, key)
+			}
+		}
+		sort.Strings(schema.Required)
+		return schema
+	}
+
+	return &Schema{}
+}
+
+// merge returns the schema describing the values described by s or other
+func (s *Schema) merge(other *Schema) *Schema {
+	if s == nil {
+		return other
+	}
+	if other == nil {
+		return s
+	}
+
+	merged := &Schema{
+		Type:    mergeTypes(s.Type, other.Type),
+		Items:   s.Items.merge(other.Items),
+		objects: s.objects || other.objects,
+	}
+
+	switch {
+	case s.objects && other.objects:
+		for _, key := range s.Required {
+			if slices.Contains(other.Required, key) {
+				merged.Required = append(merged.Required, key)
+			}
+		}
+	case s.objects:
+		merged.Required = s.Required
+	case other.objects:
+		merged.Required = other.Required
+	}
+
+	if s.Properties != nil || other.Properties != nil {
+		merged.Properties = make(map[string]*Schema)
+		for key, prop := range s.Properties {
+			merged.Properties[key] = prop
+		}
+		for key, prop := range other.Properties {
+			merged.Properties[key] = merged.Properties[key].merge(prop)
+		}
+	}
+
+	return merged
+}
+
+// mergeTypes returns the sorted union of the types a and b, integer being absorbed by number
+func mergeTypes(a, b []string) []string {
+	types := slices.Clone(a)
+	for _, t := range b {
+		if !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+
+	if slices.Contains(types, "number") {
+		types = slices.DeleteFunc(types, func(t string) bool { return t == "integer" })
+	}
+	sort.Strings(types)
+
+	return types
+}
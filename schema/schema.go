@@ -0,0 +1,644 @@
+// Package schema validates partial JSON documents against a JSON Schema, telling the values that
+// break it apart from the constraints not met yet because the document is still being streamed
+package schema
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shado1111w/partialjson"
+)
+
+// ErrInvalidSchema is returned when the schema is not a valid JSON Schema document
+var ErrInvalidSchema = errors.New("invalid schema")
+
+// Schema is a compiled JSON Schema. The keywords supported are type, enum, const, properties,
+// required, additionalProperties, items, minItems, maxItems, minLength, maxLength, pattern,
+// minimum, maximum, exclusiveMinimum, exclusiveMaximum, allOf, anyOf and oneOf, the other
+// keywords being ignored
+type Schema struct {
+	never bool
+
+	types    []string
+	enum     []any
+	hasConst bool
+	constant any
+
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema
+	items                *Schema
+	minItems, maxItems   *int
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+	minimum, maximum     *float64
+	exclusiveMinimum     *float64
+	exclusiveMaximum     *float64
+
+	allOf, anyOf, oneOf []*Schema
+}
+
+// Result is the outcome of the validation of a partial document
+type Result struct {
+	// Invalid holds the violations of the values received, which no further input can fix
+	Invalid partialjson.Violations
+	// Pending holds the constraints not met yet by the values still being streamed, such as the
+	// required members not received yet, which further input may satisfy
+	Pending partialjson.Violations
+	// Complete reports whether the whole document was received
+	Complete bool
+}
+
+// Rejected reports whether the document breaks the schema whatever input follows, so that the
+// stream producing it can be given up early
+func (r Result) Rejected() bool {
+	return len(r.Invalid) > 0
+}
+
+// Valid reports whether the document is complete and follows the schema
+func (r Result) Valid() bool {
+	return r.Complete && len(r.Invalid) == 0 && len(r.Pending) == 0
+}
+
+// ValidatePartial repairs s with p, a strict parser if nil, and validates the snapshot against the
+// JSON Schema schema. Values cut by the end of the input are checked only against the constraints
+// their received part can already break: a cut string longer than maxLength or that no enum value
+// starts with is invalid, as is a cut negative number below a non-negative minimum, while a cut
+// number out of range otherwise or a missing required member is pending
+func ValidatePartial(p *partialjson.JSONParser, s string, schema []byte) (Result, error) {
+	sch, err := Compile(schema)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return sch.ValidatePartial(p, s)
+}
+
+// ValidatePartial validates s as the function ValidatePartial does, compiling the schema once
+// for all the snapshots of a stream
+func (sch *Schema) ValidatePartial(p *partialjson.JSONParser, s string) (Result, error) {
+	if p == nil {
+		p = partialjson.NewJSONParser(true)
+	}
+
+	data, status, err := p.ParseWithStatus(s)
+	if err != nil {
+		return Result{}, err
+	}
+
+	v := &validation{status: status}
+	sch.validate(data, nil, v)
+
+	return Result{Invalid: v.invalid, Pending: v.pending, Complete: status.Complete}, nil
+}
+
+// Compile parses the JSON Schema document schema
+func Compile(schema []byte) (*Schema, error) {
+	var doc any
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	return compile(doc, "")
+}
+
+// compile returns the schema described by doc, found at the JSON pointer at
+func compile(doc any, at string) (*Schema, error) {
+	switch doc := doc.(type) {
+	case bool:
+		return &Schema{never: !doc}, nil
+	case map[string]any:
+		return compileObject(doc, at)
+	}
+
+	return nil, schemaError(at, "must be an object or a boolean")
+}
+
+// compileObject returns the schema described by the object doc, found at the JSON pointer at
+func compileObject(doc map[string]any, at string) (*Schema, error) {
+	sch := &Schema{}
+	var err error
+
+	switch t := doc["type"].(type) {
+	case nil:
+	case string:
+		sch.types = []string{t}
+	case []any:
+		for _, elem := range t {
+			name, ok := elem.(string)
+			if !ok {
+				return nil, schemaError(at+"/type", "must be a string or an array of strings")
+			}
+			sch.types = append(sch.types, name)
+		}
+	default:
+		return nil, schemaError(at+"/type", "must be a string or an array of strings")
+	}
+
+	if enum, ok := doc["enum"]; ok {
+		if sch.enum, ok = enum.([]any); !ok {
+			return nil, schemaError(at+"/enum", "must be an array")
+		}
+	}
+	sch.constant, sch.hasConst = doc["const"]
+
+	if props, ok := doc["properties"]; ok {
+		obj, ok := props.(map[string]any)
+		if !ok {
+			return nil, schemaError(at+"/properties", "must be an object")
+		}
+		sch.properties = make(map[string]*Schema, len(obj))
+		for name, prop := range obj {
+			if sch.properties[name], err = compile(prop, at+"/properties/"+escapePointer(name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if req, ok := doc["required"]; ok {
+		arr, ok := req.([]any)
+		if !ok {
+			return nil, schemaError(at+"/required", "must be an array of strings")
+		}
+		for _, elem := range arr {
+			name, ok := elem.(string)
+			if !ok {
+				return nil, schemaError(at+"/required", "must be an array of strings")
+			}
+			sch.required = append(sch.required, name)
+		}
+	}
+	if add, ok := doc["additionalProperties"]; ok {
+		if sch.additionalProperties, err = compile(add, at+"/additionalProperties"); err != nil {
+			return nil, err
+		}
+	}
+	if items, ok := doc["items"]; ok {
+		if sch.items, err = compile(items, at+"/items"); err != nil {
+			return nil, err
+		}
+	}
+
+	for key, dst := range map[string]**int{
+		"minItems": &sch.minItems, "maxItems": &sch.maxItems,
+		"minLength": &sch.minLength, "maxLength": &sch.maxLength,
+	} {
+		if *dst, err = count(doc, key, at); err != nil {
+			return nil, err
+		}
+	}
+	for key, dst := range map[string]**float64{
+		"minimum": &sch.minimum, "maximum": &sch.maximum,
+		"exclusiveMinimum": &sch.exclusiveMinimum, "exclusiveMaximum": &sch.exclusiveMaximum,
+	} {
+		if *dst, err = bound(doc, key, at); err != nil {
+			return nil, err
+		}
+	}
+
+	if pattern, ok := doc["pattern"]; ok {
+		expr, ok := pattern.(string)
+		if !ok {
+			return nil, schemaError(at+"/pattern", "must be a string")
+		}
+		if sch.pattern, err = regexp.Compile(expr); err != nil {
+			return nil, schemaError(at+"/pattern", err.Error())
+		}
+	}
+
+	for key, dst := range map[string]*[]*Schema{"allOf": &sch.allOf, "anyOf": &sch.anyOf, "oneOf": &sch.oneOf} {
+		list, ok := doc[key]
+		if !ok {
+			continue
+		}
+		arr, ok := list.([]any)
+		if !ok || len(arr) == 0 {
+			return nil, schemaError(at+"/"+key, "must be a non-empty array")
+		}
+		for i, elem := range arr {
+			sub, err := compile(elem, at+"/"+key+"/"+strconv.Itoa(i))
+			if err != nil {
+				return nil, err
+			}
+			*dst = append(*dst, sub)
+		}
+	}
+
+	return sch, nil
+}
+
+// count returns the non-negative integer of the keyword key of doc, nil if absent
+func count(doc map[string]any, key, at string) (*int, error) {
+	v, ok := doc[key]
+	if !ok {
+		return nil, nil
+	}
+
+	f, ok := v.(float64)
+	if !ok || f < 0 || f != math.Trunc(f) {
+		return nil, schemaError(at+"/"+key, "must be a non-negative integer")
+	}
+	n := int(f)
+
+	return &n, nil
+}
+
+// bound returns the number of the keyword key of doc, nil if absent
+func bound(doc map[string]any, key, at string) (*float64, error) {
+	v, ok := doc[key]
+	if !ok {
+		return nil, nil
+	}
+
+	f, ok := v.(float64)
+	if !ok {
+		return nil, schemaError(at+"/"+key, "must be a number")
+	}
+
+	return &f, nil
+}
+
+// schemaError returns the error reporting the keyword found at the JSON pointer at as invalid
+func schemaError(at, msg string) error {
+	if at == "" {
+		at = "/"
+	}
+
+	return fmt.Errorf("%w: %s %s", ErrInvalidSchema, at, msg)
+}
+
+// escapePointer escapes the member name for a JSON pointer
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// validation holds the state of the validation of a document
+type validation struct {
+	status  partialjson.Status
+	invalid partialjson.Violations
+	pending partialjson.Violations
+}
+
+// final reports whether the value at path is complete
+func (v *validation) final(path string) bool {
+	return v.status.IsFinal(path)
+}
+
+// unknown reports whether nothing of the value at path, which is val, is known yet, as when its
+// member name was received but not the value, or a strict parser dropped the string it starts
+func (v *validation) unknown(val any, path string) bool {
+	return !v.status.Complete && val == nil && v.status.Path == path && v.status.Kind != partialjson.KindNull
+}
+
+// received returns the string s found at path as received, decoded: the parser may keep the
+// escapes of a cut string as found in the input, while the status records its decoded prefix
+func (v *validation) received(s, path string) string {
+	if v.status.Complete || v.status.Path != path || v.status.Kind != partialjson.KindString {
+		return s
+	}
+	if partial, ok := v.status.Partial.(string); ok {
+		return partial
+	}
+
+	return s
+}
+
+// report appends the violation at path to the invalid violations, or to the pending ones if the
+// value can still change
+func (v *validation) report(path string, pending bool, format string, args ...any) {
+	violation := partialjson.Violation{Path: path, Message: fmt.Sprintf(format, args...)}
+	if pending {
+		v.pending = append(v.pending, violation)
+		return
+	}
+
+	v.invalid = append(v.invalid, violation)
+}
+
+// validate appends the violations of the value val found at path to v
+func (sch *Schema) validate(val any, path []string, v *validation) {
+	p := formatPath(path)
+	final := v.final(p)
+	if v.unknown(val, p) {
+		if !sch.never {
+			return
+		}
+		final = true
+	}
+
+	if sch.never {
+		v.report(p, false, "is not allowed")
+		return
+	}
+
+	if len(sch.types) > 0 && !slices.ContainsFunc(sch.types, func(t string) bool { return matchesType(val, t) }) {
+		// the received part of a number may not tell an integer yet, as in 12. cut before its fraction
+		pending := !final && slices.Contains(sch.types, "integer") && jsonType(val) == "number"
+		v.report(p, pending, "must be %s; got %s", describe(sch.types), jsonType(val))
+		return
+	}
+
+	if len(sch.enum) > 0 && !slices.ContainsFunc(sch.enum, func(e any) bool { return matches(val, e, final) }) {
+		v.report(p, !final && couldMatch(val, sch.enum), "must be one of %s", formatValues(sch.enum))
+	}
+	if sch.hasConst && !matches(val, sch.constant, final) {
+		v.report(p, !final && couldMatch(val, []any{sch.constant}), "must be %s", formatValue(sch.constant))
+	}
+
+	switch val := val.(type) {
+	case string:
+		sch.validateString(v.received(val, p), p, final, v)
+	case []any:
+		sch.validateArray(val, path, p, final, v)
+	case map[string]any:
+		sch.validateObject(val, path, p, final, v)
+	case nil, bool:
+	default:
+		sch.validateNumber(val, p, final, v)
+	}
+
+	for _, sub := range sch.allOf {
+		sub.validate(val, path, v)
+	}
+	if len(sch.anyOf) > 0 {
+		sch.validateAlternatives(sch.anyOf, val, path, p, v, false)
+	}
+	if len(sch.oneOf) > 0 {
+		sch.validateAlternatives(sch.oneOf, val, path, p, v, true)
+	}
+}
+
+// validateString appends the violations of the string s found at path p to v, s being the received
+// part of the string if it is not final
+func (sch *Schema) validateString(s, p string, final bool, v *validation) {
+	n := utf8.RuneCountInString(s)
+	if sch.maxLength != nil && n > *sch.maxLength {
+		v.report(p, false, "must be at most %d characters long; got %d", *sch.maxLength, n)
+	}
+	if sch.minLength != nil && n < *sch.minLength {
+		v.report(p, !final, "must be at least %d characters long; got %d", *sch.minLength, n)
+	}
+	if sch.pattern != nil && !sch.pattern.MatchString(s) {
+		v.report(p, !final, "must match %s", sch.pattern)
+	}
+}
+
+// validateNumber appends the violations of the number n found at path p to v. A cut number keeps
+// its sign as more digits are received, so a negative one can not reach a non-negative minimum
+// nor a positive one a non-positive maximum
+func (sch *Schema) validateNumber(n any, p string, final bool, v *validation) {
+	f, ok := toFloat(n)
+	if !ok {
+		return
+	}
+	below := func(bound float64) bool { return !final && (f >= 0 || bound < 0) }
+	above := func(bound float64) bool { return !final && (f <= 0 || bound > 0) }
+
+	if sch.minimum != nil && f < *sch.minimum {
+		v.report(p, below(*sch.minimum), "must be at least %v; got %v", *sch.minimum, n)
+	}
+	if sch.maximum != nil && f > *sch.maximum {
+		v.report(p, above(*sch.maximum), "must be at most %v; got %v", *sch.maximum, n)
+	}
+	if sch.exclusiveMinimum != nil && f <= *sch.exclusiveMinimum {
+		v.report(p, below(*sch.exclusiveMinimum), "must be greater than %v; got %v", *sch.exclusiveMinimum, n)
+	}
+	if sch.exclusiveMaximum != nil && f >= *sch.exclusiveMaximum {
+		v.report(p, above(*sch.exclusiveMaximum), "must be less than %v; got %v", *sch.exclusiveMaximum, n)
+	}
+}
+
+// validateArray appends the violations of the array arr found at path, formatted as p, to v
+func (sch *Schema) validateArray(arr []any, path []string, p string, final bool, v *validation) {
+	if sch.maxItems != nil && len(arr) > *sch.maxItems {
+		v.report(p, false, "must have at most %d elements; got %d", *sch.maxItems, len(arr))
+	}
+	if sch.minItems != nil && len(arr) < *sch.minItems {
+		v.report(p, !final, "must have at least %d elements; got %d", *sch.minItems, len(arr))
+	}
+
+	if sch.items == nil {
+		return
+	}
+	for i, elem := range arr {
+		sch.items.validate(elem, append(path[:len(path):len(path)], strconv.Itoa(i)), v)
+	}
+}
+
+// validateObject appends the violations of the object obj found at path, formatted as p, to v
+func (sch *Schema) validateObject(obj map[string]any, path []string, p string, final bool, v *validation) {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		prop, ok := sch.properties[key]
+		if !ok {
+			prop = sch.additionalProperties
+		}
+		if prop != nil {
+			prop.validate(obj[key], append(path[:len(path):len(path)], key), v)
+		}
+	}
+
+	for _, key := range sch.required {
+		if _, ok := obj[key]; !ok {
+			v.report(formatPath(append(path[:len(path):len(path)], key)), !final, "is required")
+		}
+	}
+}
+
+// validateAlternatives appends to v the violation of the value val found at path, formatted as p,
+// matching none of the schemas alts or, if one is set, more than one of them. A value still
+// being streamed that may match an alternative once complete is pending
+func (sch *Schema) validateAlternatives(alts []*Schema, val any, path []string, p string, v *validation, one bool) {
+	var valid, possible int
+	for _, alt := range alts {
+		sub := &validation{status: v.status}
+		alt.validate(val, path, sub)
+		switch {
+		case len(sub.invalid) > 0:
+		case len(sub.pending) == 0:
+			valid++
+		default:
+			possible++
+		}
+	}
+
+	switch {
+	case one && valid > 1:
+		v.report(p, !v.final(p), "must match exactly one schema of oneOf; matches %d", valid)
+	case valid == 0 && possible > 0:
+		v.report(p, true, "must match a schema of %s", keyword(one))
+	case valid == 0:
+		v.report(p, false, "must match a schema of %s", keyword(one))
+	}
+}
+
+// keyword returns the keyword listing alternatives, oneOf if one is set and anyOf otherwise
+func keyword(one bool) string {
+	if one {
+		return "oneOf"
+	}
+
+	return "anyOf"
+}
+
+// matchesType reports whether the value val is of the JSON Schema type t
+func matchesType(val any, t string) bool {
+	if t == "integer" {
+		switch val := val.(type) {
+		case float64:
+			return val == math.Trunc(val)
+		case json.Number:
+			return !strings.ContainsAny(string(val), ".eE")
+		case *big.Int:
+			return true
+		}
+		return false
+	}
+	if t == "number" {
+		return jsonType(val) == "number"
+	}
+
+	return jsonType(val) == t
+}
+
+// jsonType returns the name of the JSON Schema type of the parsed value val
+func jsonType(val any) string {
+	switch val.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+
+	return "number"
+}
+
+// describe returns the description of the values of the types, e.g. "a string or null"
+func describe(types []string) string {
+	descs := make([]string, len(types))
+	for i, t := range types {
+		switch t {
+		case "array", "object", "integer":
+			descs[i] = "an " + t
+		case "null":
+			descs[i] = t
+		default:
+			descs[i] = "a " + t
+		}
+	}
+
+	return strings.Join(descs, " or ")
+}
+
+// matches reports whether the value val equals the schema value want. A value still being streamed
+// only matches if it is a scalar, containers possibly still receiving members
+func matches(val, want any, final bool) bool {
+	if f, ok := toFloat(val); ok {
+		g, ok := want.(float64)
+		return ok && f == g
+	}
+
+	switch val.(type) {
+	case []any, map[string]any:
+		if !final {
+			return false
+		}
+	}
+
+	a, err := json.Marshal(val)
+	if err != nil {
+		return false
+	}
+	b, err := json.Marshal(want)
+	if err != nil {
+		return false
+	}
+
+	return string(a) == string(b)
+}
+
+// couldMatch reports whether the value val still being streamed may equal one of the values of
+// want once complete: a cut string must be the start of a wanted string
+func couldMatch(val any, want []any) bool {
+	s, ok := val.(string)
+	if !ok {
+		return true
+	}
+
+	return slices.ContainsFunc(want, func(w any) bool {
+		ws, ok := w.(string)
+		return ok && strings.HasPrefix(ws, s)
+	})
+}
+
+// toFloat returns the number val as a float64
+func toFloat(val any) (float64, bool) {
+	switch val := val.(type) {
+	case float64:
+		return val, true
+	case json.Number:
+		f, err := val.Float64()
+		return f, err == nil
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(val).Float64()
+		return f, true
+	}
+
+	return 0, false
+}
+
+// formatValues returns the values formatted as JSON and separated with commas
+func formatValues(vals []any) string {
+	strs := make([]string, len(vals))
+	for i, val := range vals {
+		strs[i] = formatValue(val)
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+// formatValue returns val formatted as JSON
+func formatValue(val any) string {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprint(val)
+	}
+
+	return string(b)
+}
+
+// formatPath formats a path of member names and element indexes with the dotted syntax of
+// partialjson, escaping dots and backslashes in member names with a backslash
+func formatPath(path []string) string {
+	segs := make([]string, len(path))
+	for i, seg := range path {
+		segs[i] = strings.NewReplacer(`\`, `\\`, ".", `\.`).Replace(seg)
+	}
+
+	return strings.Join(segs, ".")
+}
@@ -0,0 +1,133 @@
+package schema
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/shado1111w/partialjson"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const replySchema = `{
+	"type": "object",
+	"properties": {
+		"status": {"enum": ["ok", "error"]},
+		"title": {"type": "string", "maxLength": 5},
+		"score": {"type": "integer", "minimum": 0, "maximum": 10},
+		"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2, "minItems": 1},
+		"note": {"anyOf": [{"type": "string"}, {"type": "null"}]}
+	},
+	"required": ["status", "title"],
+	"additionalProperties": false
+}`
+
+func TestValidatePartial(t *testing.T) {
+	tests := []struct {
+		input   string
+		invalid []string
+		pending []string
+	}{
+		{
+			input:   `{"status": "ok"`,
+			pending: []string{"title is required"},
+		},
+		{
+			input:   `{"status": "er`,
+			pending: []string{`status must be one of "ok", "error"`, "title is required"},
+		},
+		{
+			input:   `{"status": "done`,
+			invalid: []string{`status must be one of "ok", "error"`},
+			pending: []string{"title is required"},
+		},
+		{
+			input:   `{"title": "Hello, wor`,
+			invalid: []string{"title must be at most 5 characters long; got 10"},
+			pending: []string{"status is required"},
+		},
+		{
+			input:   `{"status": "ok", "score": "high", "title": "a`,
+			invalid: []string{"score must be an integer; got string"},
+		},
+		{
+			input:   `{"status": "ok", "title": "a", "score": 1`,
+			pending: nil,
+		},
+		{
+			input:   `{"status": "ok", "title": "a", "score": -1`,
+			invalid: []string{"score must be at least 0; got -1"},
+		},
+		{
+			input:   `{"status": "ok", "title": "a", "score": 12`,
+			pending: []string{"score must be at most 10; got 12"},
+		},
+		{
+			input: `{"status": "ok", "title": "ab\u00e9\u00e9\u00`,
+		},
+		{
+			input:   `{"tags": ["a", "b", "c"`,
+			invalid: []string{"tags must have at most 2 elements; got 3"},
+			pending: []string{"status is required", "title is required"},
+		},
+		{
+			input:   `{"extra": 1, "status": "ok"`,
+			invalid: []string{"extra is not allowed"},
+			pending: []string{"title is required"},
+		},
+		{
+			input: `{"status": "ok", "title": `,
+		},
+		{
+			input:   `{"status": "ok", "title": "a", "note": 3}`,
+			invalid: []string{"note must match a schema of anyOf"},
+		},
+		{
+			input:   `{"status": "ok", "tags": []}`,
+			invalid: []string{"tags must have at least 1 elements; got 0", "title is required"},
+		},
+	}
+
+	for _, test := range tests {
+		res, err := ValidatePartial(partialjson.NewJSONParser(false), test.input, []byte(replySchema))
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.invalid, messages(res.Invalid), test.input)
+		require.Equal(t, test.pending, messages(res.Pending), test.input)
+		require.Equal(t, len(test.invalid) > 0, res.Rejected(), test.input)
+	}
+}
+
+func TestValidatePartialComplete(t *testing.T) {
+	res, err := ValidatePartial(nil, `{"status": "ok", "title": "Hi", "tags": ["a"], "note": null}`, []byte(replySchema))
+	require.Nil(t, err)
+	require.True(t, res.Valid())
+
+	res, err = ValidatePartial(nil, `{"status": "ok", "title": "Hi"`, []byte(replySchema))
+	require.Nil(t, err)
+	require.False(t, res.Valid())
+	require.False(t, res.Rejected())
+}
+
+func TestCompile(t *testing.T) {
+	_, err := Compile([]byte(`{"type": 1}`))
+	require.ErrorIs(t, err, ErrInvalidSchema)
+	require.EqualError(t, err, "invalid schema: /type must be a string or an array of strings")
+
+	_, err = Compile([]byte(`{"properties": {"a": {"pattern": "("}}}`))
+	require.ErrorIs(t, err, ErrInvalidSchema)
+
+	_, err = Compile([]byte(`{`))
+	require.ErrorIs(t, err, ErrInvalidSchema)
+}
+
+func messages(vs partialjson.Violations) []string {
+	var msgs []string
+	for _, v := range vs {
+		msgs = append(msgs, v.String())
+	}
+
+	return msgs
+}
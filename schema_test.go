@@ -0,0 +1,40 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInferSchema(t *testing.T) {
+	schema, err := NewJSONParser(false).InferSchema(
+		`{"name":"Alice","age":30,"tags":["a"],"score":1.5}`,
+		`{"name":"Bob","age":null,"tags":[1,2.5],"extra":{"x":true}}`,
+		`{"name":"Carol","age":41,"ex`,
+		`{"name":"Dan","score":2,"nick":`,
+	)
+	require.Nil(t, err)
+
+	b, err := json.Marshal(schema)
+	require.Nil(t, err)
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"age": {"type": ["integer", "null"]},
+			"tags": {"type": "array", "items": {"type": ["number", "string"]}},
+			"score": {"type": "number"},
+			"extra": {"type": "object", "properties": {"x": {"type": "boolean"}}, "required": ["x"]}
+		},
+		"required": ["age", "name", "tags"]
+	}`, string(b))
+
+	_, err = NewJSONParser(true).InferSchema(`{"a":1}`, `oops`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
@@ -0,0 +1,47 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+// WithSkipPaths skips the values of the members at the given paths without decoding them,
+// leaving the members out of the result. It saves time and memory on big members the
+// application never reads, such as debug information. In the paths, a # segment matches
+// any member or element, \# standing for a member named #
+func WithSkipPaths(paths ...string) ParserOption {
+	return func(p *JSONParser) {
+		for _, path := range paths {
+			p.skipPaths = append(p.skipPaths, parsePattern(path))
+		}
+	}
+}
+
+// skipsPath reports whether the value at the current path is skipped
+func (p *JSONParser) skipsPath() bool {
+	for _, segs := range p.skipPaths {
+		if matchPath(p.state.path, segs) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// skipValue skips the value of the member s starts with, returning the text following it,
+// or "" if the input ended inside it
+func (p *JSONParser) skipValue(s string) string {
+	rest := skipMember(s, '}')
+	if len(rest) == 0 {
+		p.truncate(s, KindUnknown, nil)
+		return ""
+	}
+
+	s = p.trimSpace(rest)
+	if len(s) > 0 && s[0] == ',' {
+		s = p.trimSpace(s[1:])
+	}
+
+	return s
+}
@@ -0,0 +1,50 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSkipPaths(t *testing.T) {
+	parser := NewJSONParser(true, WithSkipPaths("debug", "roles.#.raw_prompt"))
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    `{"debug":{"tokens":[1,2,{"x":"}]"}]},"roles":[{"name":"我","raw_prompt":"...\"}"},{"raw_prompt":[],"name":"DJ"}]}`,
+			expected: `{"roles":[{"name":"我"},{"name":"DJ"}]}`,
+		},
+		{
+			input:    `{"question":"如何面对？","debug":{"tokens":[1,2`,
+			expected: `{"question":"如何面对？"}`,
+		},
+		{
+			input:    `{"roles":[{"name":"我","raw_prompt":"abc"}],"debug":"x"}`,
+			expected: `{"roles":[{"name":"我"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+	}
+
+	dec := NewStreamDecoder(parser)
+	snapshot, err := dec.Feed([]byte(`{"question":"如何面对？","debug":{"tokens":[1,2`))
+	require.Nil(t, err)
+	require.False(t, snapshot.Complete)
+	require.Equal(t, 38, snapshot.Committed)
+
+	parser = NewJSONParser(true, WithSkipPaths(`tags.\#`))
+	data, err := parser.EnsureJSON(`{"tags":{"#":"hash","a":"b"},"#":1}`)
+	require.Nil(t, err)
+	require.Equal(t, `{"#":1,"tags":{"a":"b"}}`, data)
+}
@@ -0,0 +1,87 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import "sort"
+
+// TypeStats counts the kinds of the values found at every path of documents. The elements
+// of arrays share the # segment, so that options.# counts the kinds of all the options
+type TypeStats map[string]map[Kind]int
+
+// TypeStats counts the kinds of the values found at every path of docs. Values that have
+// not started yet are not counted, values still being streamed are counted with their kind
+func (p *JSONParser) TypeStats(docs ...string) (TypeStats, error) {
+	stats := make(TypeStats)
+	for _, doc := range docs {
+		sp := p.session(doc)
+		data, err := sp.run()
+		if err != nil {
+			return nil, err
+		}
+
+		stats.count(data, nil, nil, sp.state.truncation)
+	}
+
+	return stats, nil
+}
+
+// count counts the kinds of v found at path and of the values inside it, generic being
+// the path with element indexes replaced by #
+func (s TypeStats) count(v any, path, generic []any, tr *truncation) {
+	if tr != nil && tr.kind == KindUnknown && len(tr.path) == len(path) && tr.covers(path) {
+		return
+	}
+
+	key := formatPath(generic)
+	if s[key] == nil {
+		s[key] = make(map[Kind]int)
+	}
+	s[key][kindOf(v)]++
+
+	switch v := v.(type) {
+	case []any:
+		for i, elem := range v {
+			s.count(elem, append(path[:len(path):len(path)], i), append(generic[:len(generic):len(generic)], wildcard{}), tr)
+		}
+	case map[string]any:
+		for name, val := range v {
+			s.count(val, append(path[:len(path):len(path)], name), append(generic[:len(generic):len(generic)], name), tr)
+		}
+	}
+}
+
+// Merge adds the counts of other to s
+func (s TypeStats) Merge(other TypeStats) {
+	for path, kinds := range other {
+		if s[path] == nil {
+			s[path] = make(map[Kind]int)
+		}
+		for kind, n := range kinds {
+			s[path][kind] += n
+		}
+	}
+}
+
+// Mixed returns the sorted paths at which values of more than one kind were found,
+// ignoring null, which is the first sign of a drifting schema
+func (s TypeStats) Mixed() []string {
+	var paths []string
+	for path, kinds := range s {
+		n := 0
+		for kind := range kinds {
+			if kind != KindNull {
+				n++
+			}
+		}
+		if n > 1 {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	return paths
+}
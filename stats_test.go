@@ -0,0 +1,35 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTypeStats(t *testing.T) {
+	parser := NewJSONParser(false)
+	stats, err := parser.TypeStats(
+		`{"options":["a","b"],"score":1,"note":null}`,
+		`{"options":"a","score":"high","note":`,
+	)
+	require.Nil(t, err)
+	require.Equal(t, TypeStats{
+		"":          {KindObject: 2},
+		"options":   {KindArray: 1, KindString: 1},
+		"options.#": {KindString: 2},
+		"score":     {KindNumber: 1, KindString: 1},
+		"note":      {KindNull: 1},
+	}, stats)
+	require.Equal(t, []string{"options", "score"}, stats.Mixed())
+
+	more, err := parser.TypeStats(`{"options":[1],"note":"x"}`)
+	require.Nil(t, err)
+	stats.Merge(more)
+	require.Equal(t, map[Kind]int{KindString: 2, KindNumber: 1}, stats["options.#"])
+	require.Equal(t, []string{"options", "options.#", "score"}, stats.Mixed())
+}
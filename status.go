@@ -0,0 +1,56 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+// Status reports whether a parsed document was complete and, if not, where the input ended
+type Status struct {
+	// Complete reports whether the whole document was received
+	Complete bool
+	// Path is the path of the value the input ended in, in the dotted syntax, empty for the document
+	Path string
+	// Kind is the kind of the value the input ended in
+	Kind Kind
+	// Offset is the offset of the first input byte not reflected in complete values
+	Offset int
+	// Partial is the received part of the value the input ended in, decoded, such as the prefix
+	// of a cut string with a trailing incomplete escape left out, nil if nothing of it is known
+	Partial any
+
+	path []any
+}
+
+// IsFinal reports whether the value at path, in the dotted syntax, is complete and will not
+// change as more input is received. The containers of the value the input ended in are not final
+func (st Status) IsFinal(path string) bool {
+	if st.Complete {
+		return true
+	}
+
+	return !pathHasPrefix(st.path, parsePath(path))
+}
+
+// ParseWithStatus parses s as EnsureJSON does, returning the repaired value and whether the
+// document was complete, which value the input ended in and what kind of value it was.
+// When the input can not be repaired, the value parsed up to the failure point is returned
+// alongside the error
+func (p *JSONParser) ParseWithStatus(s string) (any, Status, error) {
+	sp := p.session(s)
+	data, err := sp.run()
+	tr := sp.state.truncation
+	if err != nil && tr == nil {
+		return data, Status{}, err
+	}
+	if err == nil {
+		err = sp.problems()
+	}
+
+	if tr == nil {
+		return data, Status{Complete: true, Offset: len(s)}, err
+	}
+
+	return data, Status{Path: formatPath(tr.path), Kind: tr.kind, Offset: tr.offset, Partial: tr.partial, path: tr.path}, err
+}
@@ -0,0 +1,45 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseWithStatus(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Status
+	}{
+		{input: `{"a":1}`, expected: Status{Complete: true, Offset: 7}},
+		{input: `{"a":[1,{"b":"x`, expected: Status{Path: "a.1.b", Kind: KindString, Offset: 13, Partial: "x"}},
+		{input: `{"a":12`, expected: Status{Path: "a", Kind: KindNumber, Offset: 5}},
+		{input: `{"a":"x\u00e9\u00`, expected: Status{Path: "a", Kind: KindString, Offset: 5, Partial: "xé"}},
+		{input: `{"a":[1,`, expected: Status{Path: "a", Kind: KindArray, Offset: 8}},
+	}
+
+	for _, test := range tests {
+		_, status, err := NewJSONParser(false).ParseWithStatus(test.input)
+		require.Nil(t, err, test.input)
+		status.path = nil
+		require.Equal(t, test.expected, status, test.input)
+	}
+
+	data, status, err := NewJSONParser(false).ParseWithStatus(`{"question":"如何","options":["接受","挑`)
+	require.Nil(t, err)
+	require.Equal(t, map[string]any{"question": "如何", "options": []any{"接受", "挑"}}, data)
+	require.True(t, status.IsFinal("question"))
+	require.True(t, status.IsFinal("options.0"))
+	require.False(t, status.IsFinal("options.1"))
+	require.False(t, status.IsFinal("options"))
+	require.False(t, status.IsFinal(""))
+
+	_, status, err = NewJSONParser(false).ParseWithStatus(`nope`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+	require.Equal(t, Status{}, status)
+}
@@ -0,0 +1,717 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrSnapshotNotFound is returned when rewinding to a snapshot that is not retained
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+	// ErrStalled is returned when no data arrived for the stall timeout while the document is incomplete
+	ErrStalled = errors.New("stream stalled")
+)
+
+// Snapshot is the repaired state of a streamed document
+type Snapshot struct {
+	// JSON is the repaired document
+	JSON string
+	// Data is the parsed document
+	Data any
+	// Complete reports whether the whole document has been received
+	Complete bool
+	// Bytes is the number of input bytes the snapshot was built from
+	Bytes int
+	// Committed is the number of input bytes fully reflected in complete values of the snapshot,
+	// the bytes after it belonging to a value still being streamed. Consumers reading from a log
+	// or a file can checkpoint this offset, as feeding the input up to it rebuilds the same values
+	Committed int
+	// Seq is the number of chunks fed when the snapshot was taken
+	Seq int
+}
+
+// StreamDecoder accumulates the chunks of a streamed JSON document, such as the
+// deltas of a model response, and keeps a repaired snapshot of what was received so far
+type StreamDecoder struct {
+	parser     *JSONParser
+	buf        []byte
+	seq        int
+	snapshot   Snapshot
+	truncation *truncation
+	repairs    []Repair
+	problems   error
+	history    []Snapshot
+	historyLen int
+	historyPos int
+	watchers   []*watcher
+	now        func() time.Time
+	created    time.Time
+	firstFeed  time.Time
+
+	stallMu      sync.Mutex
+	stallTimeout time.Duration
+	stallTimer   *time.Timer
+	stalled      bool
+	onStall      func(idle time.Duration)
+}
+
+// watcher is notified of the snapshots of a StreamDecoder until it is closed
+type watcher struct {
+	notify func(snapshot Snapshot, tr *truncation) (bool, error)
+	close  func()
+}
+
+// StreamOption is a function that sets an option on a StreamDecoder
+type StreamOption func(*StreamDecoder)
+
+// WithHistory retains the last n snapshots, which can be inspected with History and restored with Rewind
+func WithHistory(n int) StreamOption {
+	return func(d *StreamDecoder) {
+		d.history = make([]Snapshot, n)
+	}
+}
+
+// NewStreamDecoder creates a StreamDecoder repairing the document with parser p
+func NewStreamDecoder(p *JSONParser, opts ...StreamOption) *StreamDecoder {
+	d := &StreamDecoder{parser: p, now: time.Now}
+	d.created = d.now()
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	if d.stallTimeout > 0 {
+		d.stallTimer = time.AfterFunc(d.stallTimeout, d.stall)
+	}
+
+	return d
+}
+
+// WithStallTimeout detects stalled streams: when no chunk is fed for timeout while the document
+// is incomplete, fn is called with the time since the last chunk and Err returns ErrStalled until
+// the next chunk. The timeout also applies before the first chunk. fn may be nil
+func WithStallTimeout(timeout time.Duration, fn func(idle time.Duration)) StreamOption {
+	return func(d *StreamDecoder) {
+		d.stallTimeout = timeout
+		d.onStall = fn
+	}
+}
+
+// stall is called by the stall timer
+func (d *StreamDecoder) stall() {
+	d.stallMu.Lock()
+	d.stalled = true
+	fn := d.onStall
+	d.stallMu.Unlock()
+
+	if fn != nil {
+		fn(d.stallTimeout)
+	}
+}
+
+// resetStall restarts the stall timer after a chunk was fed, stopping it once the document is complete
+func (d *StreamDecoder) resetStall() {
+	if d.stallTimer == nil {
+		return
+	}
+
+	d.stallMu.Lock()
+	defer d.stallMu.Unlock()
+	d.stalled = false
+	d.stallTimer.Stop()
+	if !d.snapshot.Complete {
+		d.stallTimer.Reset(d.stallTimeout)
+	}
+}
+
+// Err returns ErrStalled while the stream is stalled, see WithStallTimeout
+func (d *StreamDecoder) Err() error {
+	d.stallMu.Lock()
+	defer d.stallMu.Unlock()
+	if d.stalled {
+		return ErrStalled
+	}
+
+	return nil
+}
+
+// FirstValue describes the first complete value of a streamed document
+type FirstValue struct {
+	// Path is the path of the value, empty for the whole document
+	Path string
+	// Value is the value
+	Value any
+	// Bytes is the number of input bytes received when the value completed
+	Bytes int
+	// Elapsed is the time since the decoder was created
+	Elapsed time.Duration
+	// SinceFirstChunk is the time since the first chunk was fed
+	SinceFirstChunk time.Duration
+}
+
+// WithOnFirstValue sets a function called once, when the first complete value of the document
+// becomes available, or the value at path if path is not empty. It measures the time to first
+// structured output independently of the total generation time
+func WithOnFirstValue(path string, fn func(v FirstValue)) StreamOption {
+	return func(d *StreamDecoder) {
+		segs := parsePath(path)
+		d.watchers = append(d.watchers, &watcher{
+			notify: func(snapshot Snapshot, tr *truncation) (bool, error) {
+				var found []any
+				v, ok := lookupPath(snapshot.Data, segs)
+				if ok && (tr == nil || !pathHasPrefix(tr.path, segs)) {
+					found = make([]any, len(segs))
+					for i, seg := range segs {
+						found[i] = seg
+					}
+				} else if path == "" {
+					found, v, ok = firstComplete(snapshot.Data, nil, tr)
+				} else {
+					ok = false
+				}
+				if !ok {
+					return true, nil
+				}
+
+				now := d.now()
+				fn(FirstValue{
+					Path:            formatPath(found),
+					Value:           v,
+					Bytes:           snapshot.Bytes,
+					Elapsed:         now.Sub(d.created),
+					SinceFirstChunk: now.Sub(d.firstFeed),
+				})
+				return false, nil
+			},
+			close: func() {},
+		})
+	}
+}
+
+// firstComplete returns the path and the value of the first complete value found in v,
+// members being visited in key order and before their parent
+func firstComplete(v any, path []any, tr *truncation) ([]any, any, bool) {
+	switch val := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if p, child, ok := firstComplete(val[k], append(path, k), tr); ok {
+				return p, child, true
+			}
+		}
+	case []any:
+		for i, e := range val {
+			if p, child, ok := firstComplete(e, append(path, i), tr); ok {
+				return p, child, true
+			}
+		}
+	}
+
+	if !tr.covers(path) {
+		return path, v, true
+	}
+
+	return nil, nil, false
+}
+
+// Feed appends a chunk of the document and returns the updated snapshot. When the data
+// received so far can not be repaired, the error is returned and the previous snapshot is kept.
+// Problems skipped over in lenient mode are returned alongside the updated snapshot
+func (d *StreamDecoder) Feed(chunk []byte) (Snapshot, error) {
+	if err := d.parser.checkSize(len(d.buf) + len(chunk)); err != nil {
+		return d.snapshot, err
+	}
+	if d.seq == 0 {
+		d.firstFeed = d.now()
+	}
+	d.buf = append(d.buf, chunk...)
+	d.seq++
+
+	snapshot, err := d.update()
+	d.resetStall()
+	if err != nil {
+		return snapshot, err
+	}
+
+	d.record(snapshot)
+	return snapshot, errors.Join(d.problems, d.notify())
+}
+
+// notify passes the latest snapshot to the watchers, closing the ones that are done.
+// The first error returned by a watcher is returned
+func (d *StreamDecoder) notify() error {
+	var firstErr error
+	watchers := d.watchers[:0]
+	for _, w := range d.watchers {
+		more, err := w.notify(d.snapshot, d.truncation)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+
+		if more && err == nil && !d.snapshot.Complete {
+			watchers = append(watchers, w)
+		} else {
+			w.close()
+		}
+	}
+	d.watchers = watchers
+
+	return firstErr
+}
+
+// Close closes the channels of the watchers of the decoder and stops the stall detection
+func (d *StreamDecoder) Close() {
+	if d.stallTimer != nil {
+		d.stallTimer.Stop()
+	}
+
+	for _, w := range d.watchers {
+		w.close()
+	}
+	d.watchers = nil
+}
+
+// Subscription is an independent consumer of the snapshots of a StreamDecoder. Each
+// subscription buffers the snapshots on its own, so that a slow subscriber never blocks
+// the decoder nor the other subscribers
+type Subscription struct {
+	// C receives the snapshots. It is closed once the document is complete,
+	// the decoder is closed or the subscription is closed
+	C  <-chan Snapshot
+	mb *mailbox[Snapshot]
+}
+
+// Close stops the delivery of snapshots to the subscription, discarding the buffered ones
+func (s *Subscription) Close() {
+	s.mb.stop()
+}
+
+// Subscribe returns a new subscription receiving every snapshot taken after the call.
+// Subscribe and Watch must not be called concurrently with Feed
+func (d *StreamDecoder) Subscribe() *Subscription {
+	mb := newMailbox[Snapshot]()
+	d.watchers = append(d.watchers, &watcher{
+		notify: func(snapshot Snapshot, _ *truncation) (bool, error) {
+			if mb.stopped() {
+				return false, nil
+			}
+
+			mb.push(snapshot)
+			return true, nil
+		},
+		close: mb.close,
+	})
+
+	return &Subscription{C: mb.out, mb: mb}
+}
+
+// Watch returns a channel receiving the value at path, decoded into T, every time it changes
+// or completes while the document is streamed into dec. The channel is closed once the value
+// is complete, the document is complete or the decoder is closed. Values that can not be decoded
+// into T are skipped. Like subscriptions, the channel is buffered independently
+func Watch[T any](dec *StreamDecoder, path string) <-chan T {
+	mb := newMailbox[T]()
+	segs := parsePath(path)
+
+	var last []byte
+	lastComplete := false
+	dec.watchers = append(dec.watchers, &watcher{
+		notify: func(snapshot Snapshot, tr *truncation) (bool, error) {
+			v, ok := lookupPath(snapshot.Data, segs)
+			if !ok {
+				return true, nil
+			}
+
+			complete := tr == nil || !pathHasPrefix(tr.path, segs)
+			if v == nil && !complete {
+				return true, nil
+			}
+
+			b, err := json.Marshal(v)
+			if err != nil || (bytes.Equal(b, last) && complete == lastComplete) {
+				return !complete, nil
+			}
+
+			var t T
+			if err := json.Unmarshal(b, &t); err != nil {
+				return !complete, nil
+			}
+
+			last, lastComplete = b, complete
+			mb.push(t)
+			return !complete, nil
+		},
+		close: mb.close,
+	})
+
+	return mb.out
+}
+
+// update repairs the data received so far into a new snapshot
+func (d *StreamDecoder) update() (Snapshot, error) {
+	input := string(d.buf)
+	sp := d.parser.session(input)
+	data, err := sp.run()
+	if err != nil {
+		return d.snapshot, err
+	}
+
+	b, err := sp.encode(data)
+	if err != nil {
+		return d.snapshot, err
+	}
+
+	d.truncation = sp.state.truncation
+	d.repairs = sp.state.repairs
+	d.problems = sp.problems()
+	d.snapshot = Snapshot{
+		JSON:      string(b),
+		Data:      data,
+		Complete:  d.truncation == nil,
+		Bytes:     len(input),
+		Committed: len(input),
+		Seq:       d.seq,
+	}
+	if d.truncation != nil {
+		d.snapshot.Committed = d.truncation.offset
+	}
+
+	return d.snapshot, nil
+}
+
+// Snapshot returns the latest snapshot
+func (d *StreamDecoder) Snapshot() Snapshot {
+	return d.snapshot
+}
+
+// record appends a snapshot to the history ring buffer
+func (d *StreamDecoder) record(snapshot Snapshot) {
+	if len(d.history) == 0 {
+		return
+	}
+
+	d.history[d.historyPos] = snapshot
+	d.historyPos = (d.historyPos + 1) % len(d.history)
+	d.historyLen = min(d.historyLen+1, len(d.history))
+}
+
+// History returns the retained snapshots, oldest first
+func (d *StreamDecoder) History() []Snapshot {
+	history := make([]Snapshot, 0, d.historyLen)
+	for i := 0; i < d.historyLen; i++ {
+		history = append(history, d.history[(d.historyPos-d.historyLen+i+len(d.history))%len(d.history)])
+	}
+
+	return history
+}
+
+// Rewind restores the decoder to the i-th snapshot returned by History, discarding
+// the input received after it and the snapshots taken after it
+func (d *StreamDecoder) Rewind(i int) (Snapshot, error) {
+	if i < 0 || i >= d.historyLen {
+		return d.snapshot, ErrSnapshotNotFound
+	}
+
+	snapshot := d.History()[i]
+	d.buf = d.buf[:snapshot.Bytes]
+	d.seq = snapshot.Seq
+	d.historyPos = (d.historyPos - d.historyLen + i + 1 + len(d.history)) % len(d.history)
+	d.historyLen = i + 1
+
+	return d.update()
+}
+
+// DumpState returns a human-readable description of the decoder state: the bytes consumed,
+// the containers still open, the path being streamed, the pending string and the repairs
+// applied to the latest snapshot
+func (d *StreamDecoder) DumpState() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "bytes consumed: %d\n", len(d.buf))
+	fmt.Fprintf(&sb, "snapshot bytes: %d\n", d.snapshot.Bytes)
+	fmt.Fprintf(&sb, "complete: %t\n", d.snapshot.Complete)
+
+	if tr := d.truncation; tr != nil {
+		containers := make([]string, 0, len(tr.path)+1)
+		for _, seg := range tr.path {
+			if _, ok := seg.(int); ok {
+				containers = append(containers, KindArray.String())
+			} else {
+				containers = append(containers, KindObject.String())
+			}
+		}
+		if tr.kind == KindObject || tr.kind == KindArray {
+			containers = append(containers, tr.kind.String())
+		}
+
+		fmt.Fprintf(&sb, "open containers: %s\n", strings.Join(containers, " > "))
+		fmt.Fprintf(&sb, "current path: %s\n", formatPathOrRoot(tr.path))
+		fmt.Fprintf(&sb, "pending kind: %s\n", tr.kind)
+		if partial, ok := tr.partial.(string); ok && tr.kind == KindString {
+			fmt.Fprintf(&sb, "pending string: %s\n", strconv.Quote(partial))
+		}
+	}
+
+	fmt.Fprintf(&sb, "repairs: %d\n", len(d.repairs))
+	for _, r := range d.repairs {
+		fmt.Fprintf(&sb, "  offset %d: %s -> %s\n", r.Offset, strconv.Quote(r.Original), strconv.Quote(r.Replacement))
+	}
+
+	return sb.String()
+}
+
+func formatPathOrRoot(path []any) string {
+	if len(path) == 0 {
+		return "(root)"
+	}
+
+	return formatPath(path)
+}
+
+// DecodeBase64 decodes the base64 content of the string at path with enc while it is streamed,
+// writing the decoded bytes to w as soon as they are available. Whitespace is ignored, and for
+// data URLs the part up to ";base64," is skipped. Decoding and write errors are returned by Feed
+func (d *StreamDecoder) DecodeBase64(path string, enc *base64.Encoding, w io.Writer) {
+	segs := parsePath(path)
+	consumed := 0
+	d.watchers = append(d.watchers, &watcher{
+		notify: func(snapshot Snapshot, tr *truncation) (bool, error) {
+			text, complete, ok := stringAt(snapshot, tr, segs)
+			if !ok {
+				return true, nil
+			}
+
+			if !complete && strings.HasPrefix("data:", text) {
+				return true, nil
+			}
+			if strings.HasPrefix(text, "data:") {
+				i := strings.Index(text, ";base64,")
+				if i < 0 {
+					return !complete, nil
+				}
+				text = text[i+len(";base64,"):]
+			}
+
+			text = strings.Map(func(r rune) rune {
+				if unicode.IsSpace(r) {
+					return -1
+				}
+				return r
+			}, text)
+
+			n := len(text) - consumed
+			if !complete {
+				n = n / 4 * 4
+			}
+			if n <= 0 {
+				return !complete, nil
+			}
+
+			chunk := text[consumed : consumed+n]
+			consumed += n
+
+			decoder := enc
+			if complete && len(chunk)%4 != 0 {
+				decoder = enc.WithPadding(base64.NoPadding)
+			}
+
+			b, err := decoder.DecodeString(chunk)
+			if err != nil {
+				return false, err
+			}
+			if _, err := w.Write(b); err != nil {
+				return false, err
+			}
+
+			return !complete, nil
+		},
+		close: func() {},
+	})
+}
+
+// stringAt returns the string at the path segs of a snapshot and whether it is complete,
+// the streamed prefix being returned for a pending string
+func stringAt(snapshot Snapshot, tr *truncation, segs []string) (string, bool, bool) {
+	if tr != nil && tr.kind == KindString && len(tr.path) == len(segs) && pathHasPrefix(tr.path, segs) {
+		partial, ok := tr.partial.(string)
+		return partial, false, ok
+	}
+
+	v, ok := lookupPath(snapshot.Data, segs)
+	str, isString := v.(string)
+	if !ok || !isString {
+		return "", false, false
+	}
+
+	return str, tr == nil || !pathHasPrefix(tr.path, segs), true
+}
+
+// ChunkString emits the string at path in pieces of at most size bytes while it is streamed,
+// each piece ending on a rune boundary so that no UTF-8 sequence or escape is split. The
+// pieces hold the decoded string, and concatenated they are the complete string. A rune longer
+// than size is emitted alone. Errors returned by emit are returned by Feed
+func (d *StreamDecoder) ChunkString(path string, size int, emit func(chunk string) error) {
+	segs := parsePath(path)
+	emitted := 0
+	d.watchers = append(d.watchers, &watcher{
+		notify: func(snapshot Snapshot, tr *truncation) (bool, error) {
+			text, complete, ok := stringAt(snapshot, tr, segs)
+			if !ok || len(text) < emitted {
+				return true, nil
+			}
+
+			text = text[emitted:]
+			if !complete {
+				// the streamed prefix may end inside a rune
+				i := len(text) - 1
+				for i > 0 && i > len(text)-utf8.UTFMax && !utf8.RuneStart(text[i]) {
+					i--
+				}
+				if i >= 0 && !utf8.FullRuneInString(text[i:]) {
+					text = text[:i]
+				}
+			}
+
+			for len(text) > 0 {
+				n := min(size, len(text))
+				for n > 0 && n < len(text) && !utf8.RuneStart(text[n]) {
+					n--
+				}
+				if n == 0 {
+					_, n = utf8.DecodeRuneInString(text)
+				}
+
+				if err := emit(text[:n]); err != nil {
+					return false, err
+				}
+				emitted += n
+				text = text[n:]
+			}
+
+			return !complete, nil
+		},
+		close: func() {},
+	})
+}
+
+// WriteNDJSON writes each element of the array at path to w as one NDJSON line as soon as the
+// element is complete, so that line-oriented consumers can start before the array is closed.
+// An empty path is the top-level array. Write errors are returned by Feed
+func (d *StreamDecoder) WriteNDJSON(path string, w io.Writer) {
+	segs := parsePath(path)
+	written := 0
+	d.watchers = append(d.watchers, &watcher{
+		notify: func(snapshot Snapshot, tr *truncation) (bool, error) {
+			v, ok := lookupPath(snapshot.Data, segs)
+			arr, isArray := v.([]any)
+			if !ok || !isArray {
+				return true, nil
+			}
+
+			for ; written < len(arr); written++ {
+				if tr != nil && pathHasPrefix(tr.path, append(segs[:len(segs):len(segs)], strconv.Itoa(written))) {
+					break
+				}
+
+				b, err := json.Marshal(arr[written])
+				if err != nil {
+					return false, err
+				}
+				if _, err := w.Write(append(b, '\n')); err != nil {
+					return false, err
+				}
+			}
+
+			return tr != nil && pathHasPrefix(tr.path, segs), nil
+		},
+		close: func() {},
+	})
+}
+
+// Stream repairs the document whose chunks are received from chunks, such as the deltas of a model
+// response, in a goroutine sending the snapshot of the document to the first channel after every
+// chunk. Chunks whose text can not be repaired yet, as when a chunk ends inside true, are not sent
+// a snapshot. The goroutine returns once the document is complete, chunks is closed or ctx is done,
+// closing both channels. The second channel receives at most one error before being closed: ctx.Err()
+// if ctx is done first, the error of a parser limit such as a SizeLimitError or a DepthLimitError,
+// which ends the stream, the error of the last chunk if the document can not be repaired once chunks
+// is closed, or else the problems skipped over in lenient mode alongside the last snapshot. Canceling
+// ctx stops the goroutine even if the snapshots are not received anymore
+func (p *JSONParser) Stream(ctx context.Context, chunks <-chan []byte) (<-chan Snapshot, <-chan error) {
+	snapshots := make(chan Snapshot)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(snapshots)
+
+		dec := NewStreamDecoder(p)
+		defer dec.Close()
+
+		var lastErr error
+		for {
+			var chunk []byte
+			var ok bool
+			select {
+			case chunk, ok = <-chunks:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+			if !ok {
+				if lastErr != nil {
+					errs <- lastErr
+				}
+				return
+			}
+
+			fed := dec.seq + 1
+			snapshot, err := dec.Feed(chunk)
+			if isLimitError(err) {
+				errs <- err
+				return
+			}
+			// the error of a chunk not repaired, or the problems of the snapshot
+			lastErr = err
+			if snapshot.Seq != fed {
+				continue
+			}
+
+			select {
+			case snapshots <- snapshot:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+			if snapshot.Complete {
+				if lastErr != nil {
+					errs <- lastErr
+				}
+				return
+			}
+		}
+	}()
+
+	return snapshots, errs
+}
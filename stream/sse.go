@@ -0,0 +1,170 @@
+// Package stream consumes streamed model responses, delivering the repaired snapshots
+// of the JSON document they carry
+package stream
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/shado1111w/partialjson"
+)
+
+// Snapshot is the repaired state of the streamed document
+type Snapshot = partialjson.Snapshot
+
+// doneData is the data of the event ending an OpenAI-style stream
+const doneData = "[DONE]"
+
+// config holds the settings of ConsumeSSE
+type config struct {
+	parser *partialjson.JSONParser
+	delta  func(data string) (string, error)
+}
+
+// Option is a function that sets an option of ConsumeSSE
+type Option func(*config)
+
+// WithParser sets the parser repairing the document, a strict parser by default
+func WithParser(p *partialjson.JSONParser) Option {
+	return func(c *config) {
+		c.parser = p
+	}
+}
+
+// WithDelta sets the function returning the text of the document carried by the data of an event,
+// such as OpenAIDelta. By default the data is the text itself. An error ends the stream
+func WithDelta(fn func(data string) (string, error)) Option {
+	return func(c *config) {
+		c.delta = fn
+	}
+}
+
+// OpenAIDelta returns the content delta of the first choice of an OpenAI chat completion chunk
+// given as the data of its event, that is choices[0].delta.content, or "" if the chunk has none
+func OpenAIDelta(data string) (string, error) {
+	var chunk struct {
+		Choices []struct {
+			Delta struct {
+				Content string `json:"content"`
+			} `json:"delta"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+		return "", err
+	}
+	if len(chunk.Choices) == 0 {
+		return "", nil
+	}
+
+	return chunk.Choices[0].Delta.Content, nil
+}
+
+// ConsumeSSE reads the server-sent events of r, accumulates the text their data carries and sends
+// the repaired snapshot of the document to ch after every event that changed it. It returns once the
+// document is complete, a [DONE] event is received or r ends, closing ch, and returns the error of r,
+// of the delta function or ctx.Err() if ctx is done first. Events whose text can not be repaired yet,
+// as when a chunk ends inside true, are not sent a snapshot; when the events end without a complete
+// document, the error the accumulated text could not be repaired with, if any, is returned. The
+// events are dispatched on blank lines, the data lines of an event being joined with newlines and
+// the other fields ignored. Reading r is not interrupted by ctx: r should be closed when ctx is
+// done, as the body of an http.Response obtained with ctx is
+func ConsumeSSE(ctx context.Context, r io.Reader, ch chan<- Snapshot, opts ...Option) error {
+	defer close(ch)
+
+	c := config{
+		parser: partialjson.NewJSONParser(true),
+		delta:  func(data string) (string, error) { return data, nil },
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	dec := partialjson.NewStreamDecoder(c.parser)
+	defer dec.Close()
+
+	fed := 0
+	// feedErr is the error of the last event, nil once the accumulated text is repaired again
+	var feedErr error
+	complete := false
+	err := readEvents(ctx, r, func(data string) (bool, error) {
+		if data == doneData {
+			return false, nil
+		}
+
+		text, err := c.delta(data)
+		if err != nil || text == "" {
+			return err == nil, err
+		}
+
+		fed++
+		snapshot, err := dec.Feed([]byte(text))
+		feedErr = err
+		if snapshot.Seq != fed {
+			return true, nil
+		}
+		complete = snapshot.Complete
+
+		select {
+		case ch <- snapshot:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+		return !snapshot.Complete, nil
+	})
+	if err == nil && !complete {
+		return feedErr
+	}
+
+	return err
+}
+
+// readEvents calls fn with the data of every event read from r until fn reports false or returns an error
+func readEvents(ctx context.Context, r io.Reader, fn func(data string) (bool, error)) error {
+	br := bufio.NewReader(r)
+	var data strings.Builder
+	hasData := false
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		line = bytes.TrimRight(line, "\r\n")
+		if len(line) == 0 && hasData {
+			more, ferr := fn(data.String())
+			if ferr != nil || !more {
+				return ferr
+			}
+			data.Reset()
+			hasData = false
+		} else if value, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.Write(bytes.TrimPrefix(value, []byte(" ")))
+			hasData = true
+		}
+
+		if err == io.EOF {
+			if hasData {
+				_, ferr := fn(data.String())
+				return ferr
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package stream
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"context"
+	"errors"
+	"github.com/shado1111w/partialjson"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestConsumeSSE(t *testing.T) {
+	body := ": keep-alive\n\n" +
+		"data: {\"name\":\"Al\n\n" +
+		"event: delta\ndata: ice\",\"ok\":tr\n\n" +
+		"data: ue,\"tags\":[\"a\"\n\n" +
+		"data: ]}\n\n" +
+		"data: [DONE]\n\n"
+
+	ch := make(chan Snapshot, 10)
+	err := ConsumeSSE(context.Background(), strings.NewReader(body), ch)
+	require.Nil(t, err)
+
+	var snapshots []string
+	for s := range ch {
+		snapshots = append(snapshots, s.JSON)
+	}
+	require.Equal(t, []string{`{"name":null}`, `{"name":"Alice","ok":true,"tags":["a"]}`, `{"name":"Alice","ok":true,"tags":["a"]}`}, snapshots)
+
+	openai := "data: {\"choices\":[{\"delta\":{\"content\":\"{\\\"a\\\":\"}}]}\r\n\r\n" +
+		"data: {\"choices\":[{\"delta\":{}}]}\r\n\r\n" +
+		"data: {\"choices\":[{\"delta\":{\"content\":\"\\\"x\"}}]}"
+	ch = make(chan Snapshot, 10)
+	err = ConsumeSSE(context.Background(), strings.NewReader(openai), ch,
+		WithDelta(OpenAIDelta), WithParser(partialjson.NewJSONParser(false)))
+	require.Nil(t, err)
+	var last Snapshot
+	for s := range ch {
+		last = s
+	}
+	require.Equal(t, `{"a":"x"}`, last.JSON)
+	require.False(t, last.Complete)
+
+	ch = make(chan Snapshot, 10)
+	err = ConsumeSSE(context.Background(), strings.NewReader("data: x\n\n"), ch, WithDelta(OpenAIDelta))
+	require.NotNil(t, err)
+
+	ch = make(chan Snapshot, 10)
+	err = ConsumeSSE(context.Background(), strings.NewReader("data: {\"a\":1,\"b\":x\n\ndata: [DONE]\n\n"), ch)
+	require.ErrorIs(t, err, partialjson.ErrUnexpectedToken)
+
+	ch = make(chan Snapshot, 10)
+	err = ConsumeSSE(context.Background(), strings.NewReader("data: {\"a\":tr\n\ndata: ue}\n\n"), ch)
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ch = make(chan Snapshot)
+	err = ConsumeSSE(ctx, strings.NewReader(body), ch)
+	require.True(t, errors.Is(err, context.Canceled))
+	_, ok := <-ch
+	require.False(t, ok)
+
+	pr, pw := io.Pipe()
+	ch = make(chan Snapshot)
+	ctx, cancel = context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- ConsumeSSE(ctx, pr, ch) }()
+	_, err = pw.Write([]byte("data: {\"a\":1,\n\n"))
+	require.Nil(t, err)
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	pw.Close()
+}
@@ -0,0 +1,127 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+)
+
+// StreamParser repairs a document pushed chunk by chunk, such as the deltas of a model response.
+// Unlike StreamDecoder, it does not parse the accumulated text again on every chunk: the state
+// of the repair is kept between chunks, so that the work done across a stream is linear in its
+// length. The document is repaired as by the RepairStream method of the parser it was created with
+type StreamParser struct {
+	out  strings.Builder
+	r    *streamRepairer
+	done bool
+	err  error
+	// current caches the document Current returned, valid while fed is unchanged
+	current string
+	fed     int
+}
+
+// NewStreamParser creates a StreamParser repairing with the options of p RepairStream applies
+func (p *JSONParser) NewStreamParser() *StreamParser {
+	sp := &StreamParser{fed: -1}
+	sp.r = p.newStreamRepairer(&sp.out)
+	return sp
+}
+
+// Feed pushes the next chunk of the document. Text following the top-level value is ignored.
+// Once the chunks can not be repaired, the error is returned by every call
+func (sp *StreamParser) Feed(chunk []byte) error {
+	if sp.err != nil || sp.done {
+		return sp.err
+	}
+
+	for _, b := range chunk {
+		done, err := sp.r.feedByte(b)
+		if err != nil {
+			sp.err = err
+			return sp.err
+		}
+		if done {
+			sp.done = true
+			break
+		}
+	}
+
+	return nil
+}
+
+// Current returns the document fed so far repaired: the value cut by the end of the last chunk is
+// completed and the open containers are closed. If a chunk could not be repaired, the document
+// repaired up to the failure point is returned alongside the error. The document is built once per
+// chunk and not copied at all once complete; WriteTo writes it without building a string
+func (sp *StreamParser) Current() (string, error) {
+	if !sp.r.started {
+		if sp.err != nil {
+			return "", sp.err
+		}
+		return "", ErrUnexpectedToken
+	}
+
+	if sp.done {
+		sp.r.w.Flush()
+		return sp.out.String(), sp.err
+	}
+	if sp.fed != sp.r.offset {
+		var b strings.Builder
+		if _, err := sp.WriteTo(&b); err != nil {
+			return "", err
+		}
+		sp.current, sp.fed = b.String(), sp.r.offset
+	}
+
+	return sp.current, sp.err
+}
+
+// WriteTo writes the document Current returns to w, implementing io.WriterTo. Nothing is written
+// before the first value starts
+func (sp *StreamParser) WriteTo(w io.Writer) (int64, error) {
+	if !sp.r.started {
+		return 0, nil
+	}
+
+	sp.r.w.Flush()
+	n, err := io.WriteString(w, sp.out.String())
+	if err != nil || sp.done {
+		return int64(n), err
+	}
+
+	// complete a copy of the state, the next chunks continuing from the original
+	r := *sp.r
+	cw := &countWriter{w: w}
+	r.w = bufio.NewWriter(cw)
+	r.stack = slices.Clone(r.stack)
+	r.token = slices.Clone(r.token)
+	r.hexBytes = slices.Clone(r.hexBytes)
+	r.finish()
+	err = r.w.Flush()
+
+	return int64(n) + cw.n, err
+}
+
+// countWriter counts the bytes written to w
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	return n, err
+}
+
+// Done reports whether the top-level value is complete
+func (sp *StreamParser) Done() bool {
+	return sp.done
+}
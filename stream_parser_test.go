@@ -0,0 +1,80 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStreamParser(t *testing.T) {
+	chunks := []string{`{"question":"如`, `何面对？","options":[`, `"接受",tr`, `ue,12`, `.5],"x":`, `null}`, ` trailing`}
+	expected := []string{
+		`{"question":"如"}`,
+		`{"question":"如何面对？","options":[]}`,
+		`{"question":"如何面对？","options":["接受",null]}`,
+		`{"question":"如何面对？","options":["接受",true,12]}`,
+		`{"question":"如何面对？","options":["接受",true,12.5],"x":null}`,
+		`{"question":"如何面对？","options":["接受",true,12.5],"x":null}`,
+		`{"question":"如何面对？","options":["接受",true,12.5],"x":null}`,
+	}
+
+	sp := NewJSONParser(false).NewStreamParser()
+	_, err := sp.Current()
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+
+	for i, chunk := range chunks {
+		require.Nil(t, sp.Feed([]byte(chunk)))
+		data, err := sp.Current()
+		require.Nil(t, err)
+		require.Equal(t, expected[i], data, chunk)
+	}
+	require.True(t, sp.Done())
+
+	sp = NewJSONParser(false).NewStreamParser()
+	input := `{"a":[1,{"b":"x\"yé"}],"c":false}`
+	for i := range len(input) {
+		require.Nil(t, sp.Feed([]byte(input[i:i+1])))
+		_, err := sp.Current()
+		require.Nil(t, err)
+	}
+	data, err := sp.Current()
+	require.Nil(t, err)
+	require.Equal(t, input, data)
+
+	sp = NewJSONParser(false).NewStreamParser()
+	require.Nil(t, sp.Feed([]byte(`{"a":1,`)))
+	err = sp.Feed([]byte(`:`))
+	require.Equal(t, &ParseError{Offset: 7, Err: ErrUnexpectedToken}, err)
+	require.Equal(t, err, sp.Feed([]byte(`}`)))
+	data, cerr := sp.Current()
+	require.Equal(t, err, cerr)
+	require.Equal(t, `{"a":1}`, data)
+}
+
+func TestStreamParserOptions(t *testing.T) {
+	sp := NewJSONParser(true, WithLenient()).NewStreamParser()
+	require.Nil(t, sp.Feed([]byte(`{"a":"x\qy","b":[1,`)))
+	data, err := sp.Current()
+	require.Nil(t, err)
+	require.Equal(t, `{"a":"x\\qy","b":[1]}`, data)
+
+	var buf bytes.Buffer
+	n, err := sp.WriteTo(&buf)
+	require.Nil(t, err)
+	require.Equal(t, int64(len(data)), n)
+	require.Equal(t, data, buf.String())
+
+	sp = NewJSONParser(true).NewStreamParser()
+	err = sp.Feed([]byte(`{"a":"x\q`))
+	require.Equal(t, &ParseError{Offset: 8, Err: ErrUnexpectedToken}, err)
+
+	sp = NewJSONParser(true, WithMaxDepth(1)).NewStreamParser()
+	var lerr *DepthLimitError
+	require.ErrorAs(t, sp.Feed([]byte(`{"a":[`)), &lerr)
+}
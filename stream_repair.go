@@ -0,0 +1,451 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+)
+
+// numberRe matches a complete JSON number
+var numberRe = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// what a streamRepairer expects next at the current level
+const (
+	expectValue = iota
+	expectKey
+	expectColon
+	expectCommaOrEnd
+)
+
+// streamRepairer repairs a document read byte by byte, holding back only what may
+// still change: a comma, a scalar token, an escape sequence or the bytes of a rune
+type streamRepairer struct {
+	w           *bufio.Writer
+	stack       []byte
+	expect      int
+	first       bool
+	comma       bool
+	token       []byte
+	inString    bool
+	inKey       bool
+	escape      []byte
+	partialRune []byte
+	offset      int
+	started     bool
+	// hexBytes holds the bytes of the \xHH escapes read last, written once the run of escapes ends
+	hexBytes []byte
+	// lenient repairs the nonstandard escapes and the raw control characters of strings instead of failing
+	lenient       bool
+	maxDepth      int
+	maxInputBytes int
+}
+
+// RepairStream repairs the document read from src into dst with memory bounded by the nesting
+// depth rather than the size of the document, so that arbitrarily large files can be repaired.
+// Complete values are copied through without whitespace and in input order as they are read.
+// When src ends, a cut string keeps its streamed prefix, a cut number keeps its complete prefix,
+// a member missing its value gets null and the open containers are closed. Text following the
+// top-level value is ignored.
+//
+// Nonstandard escapes such as \q and raw control characters in strings fail with ErrUnexpectedToken,
+// unless WithLenient or WithLenientEscapes is set: they are then repaired as EnsureJSON does, but
+// for \xHH escapes being read as UTF-8 bytes only within a run of such escapes. WithMaxDepth and
+// WithMaxInputBytes apply, the DepthLimitError having no path. The other options of p, its
+// strictness included, are ignored, as what was copied through can not be taken back
+func (p *JSONParser) RepairStream(dst io.Writer, src io.Reader) error {
+	r := p.newStreamRepairer(dst)
+	if err := r.readValue(bufio.NewReader(src), true); err != nil {
+		return err
+	}
+
+	if !r.started {
+		return ErrUnexpectedToken
+	}
+
+	r.finish()
+	return r.w.Flush()
+}
+
+// newStreamRepairer returns a streamRepairer writing to w with the options of p it applies
+func (p *JSONParser) newStreamRepairer(w io.Writer) *streamRepairer {
+	return &streamRepairer{
+		w:             bufio.NewWriter(w),
+		lenient:       p.lenient || p.lenientEscapes,
+		maxDepth:      p.maxDepth,
+		maxInputBytes: p.maxInputBytes,
+	}
+}
+
+// readValue feeds the bytes read from br up to the end of the top-level value or of br. When flush
+// is set, what was repaired is passed on before possibly blocking on br
+func (r *streamRepairer) readValue(br *bufio.Reader, flush bool) error {
+	for {
+		if flush && br.Buffered() == 0 {
+			// pass on what was repaired before possibly blocking on br
+			if err := r.w.Flush(); err != nil {
+				return err
+			}
+		}
+
+		b, err := br.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		done, err := r.feedByte(b)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// feedByte processes the next byte b of the input as feed does, locating the syntax errors and
+// checking the size limit
+func (r *streamRepairer) feedByte(b byte) (bool, error) {
+	if r.maxInputBytes > 0 && r.offset >= r.maxInputBytes {
+		return false, &SizeLimitError{Limit: r.maxInputBytes, Size: r.offset + 1}
+	}
+
+	done, err := r.feed(b)
+	if errors.Is(err, ErrUnexpectedToken) {
+		return false, &ParseError{Offset: r.offset, Err: err}
+	}
+	if err != nil {
+		return false, err
+	}
+	r.offset++
+
+	return done, nil
+}
+
+// feed processes the next byte b, reporting whether the top-level value is complete
+func (r *streamRepairer) feed(b byte) (bool, error) {
+	if r.inString {
+		return false, r.feedString(b)
+	}
+
+	if len(r.token) > 0 {
+		switch b {
+		case ' ', '\t', '\n', '\r', ',', ']', '}':
+			if err := r.endToken(); err != nil {
+				return false, err
+			}
+		default:
+			r.token = append(r.token, b)
+			return false, nil
+		}
+	}
+
+	switch b {
+	case ' ', '\t', '\n', '\r':
+		return false, nil
+	}
+	if len(r.stack) == 0 && b != '{' && b != '[' {
+		return false, ErrUnexpectedToken
+	}
+
+	switch b {
+	case '"':
+		if r.expect != expectValue && r.expect != expectKey {
+			return false, ErrUnexpectedToken
+		}
+		r.writeComma()
+		r.w.WriteByte(b)
+		r.inString, r.inKey = true, r.expect == expectKey
+		return false, nil
+	case '{', '[':
+		if r.expect != expectValue {
+			return false, ErrUnexpectedToken
+		}
+		if r.maxDepth > 0 && len(r.stack) >= r.maxDepth {
+			return false, &DepthLimitError{Limit: r.maxDepth, Offset: r.offset}
+		}
+		r.writeComma()
+		r.w.WriteByte(b)
+		r.open(b)
+		return false, nil
+	case '}', ']':
+		if len(r.stack) == 0 || rune(r.stack[len(r.stack)-1]) != getReverseDelim(rune(b)) {
+			return false, ErrUnexpectedToken
+		}
+		if r.expect != expectCommaOrEnd && !r.first && !r.comma {
+			r.completeMember()
+		}
+		r.comma = false
+		return r.close(), nil
+	case ',':
+		if r.expect != expectCommaOrEnd {
+			return false, ErrUnexpectedToken
+		}
+		r.comma = true
+		r.expect = r.memberStart()
+		return false, nil
+	case ':':
+		if r.expect != expectColon {
+			return false, ErrUnexpectedToken
+		}
+		r.w.WriteByte(b)
+		r.expect = expectValue
+		return false, nil
+	}
+
+	if r.expect != expectValue || !(b == '-' || b == 't' || b == 'f' || b == 'n' || '0' <= b && b <= '9') {
+		return false, ErrUnexpectedToken
+	}
+	r.writeComma()
+	r.token = append(r.token, b)
+	return false, nil
+}
+
+// feedString processes the byte b of a string
+func (r *streamRepairer) feedString(b byte) error {
+	if len(r.hexBytes) > 0 && len(r.escape) == 0 && b != '\\' {
+		r.flushHex()
+	}
+
+	switch {
+	case len(r.escape) > 0:
+		r.escape = append(r.escape, b)
+		return r.feedEscape()
+	case len(r.partialRune) > 0 || b >= utf8.RuneSelf:
+		r.partialRune = append(r.partialRune, b)
+		if utf8.FullRune(r.partialRune) {
+			r.w.Write(r.partialRune)
+			r.partialRune = r.partialRune[:0]
+		}
+	case b == '\\':
+		r.escape = append(r.escape, b)
+	case b == '"':
+		r.w.WriteByte(b)
+		r.inString = false
+		if r.inKey {
+			r.expect = expectColon
+		} else {
+			r.expect = expectCommaOrEnd
+		}
+	case b < 0x20:
+		if !r.lenient {
+			return ErrUnexpectedToken
+		}
+		r.writeEscaped([]byte{b})
+	default:
+		r.w.WriteByte(b)
+	}
+
+	return nil
+}
+
+// feedEscape processes the escape sequence read so far, writing it once complete
+func (r *streamRepairer) feedEscape() error {
+	esc := r.escape
+	last := esc[len(esc)-1]
+	switch esc[1] {
+	case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+		r.flushHex()
+		r.w.Write(esc)
+		r.escape = r.escape[:0]
+		return nil
+	case 'u':
+		if len(esc) == 2 || isHexDigit(last) {
+			if len(esc) == 6 {
+				r.flushHex()
+				r.w.Write(esc)
+				r.escape = r.escape[:0]
+			}
+			return nil
+		}
+	case 'x':
+		if r.lenient && (len(esc) == 2 || isHexDigit(last)) {
+			if len(esc) == 4 {
+				v, _ := strconv.ParseUint(string(esc[2:]), 16, 8)
+				r.hexBytes = append(r.hexBytes, byte(v))
+				r.escape = r.escape[:0]
+			}
+			return nil
+		}
+	}
+
+	if !r.lenient {
+		return ErrUnexpectedToken
+	}
+
+	// the backslash of an unknown escape is kept literally, as in \q, and what follows it is read again
+	rest := slices.Clone(esc[1:])
+	r.escape = r.escape[:0]
+	r.flushHex()
+	r.w.WriteString(`\\`)
+	for _, c := range rest {
+		if err := r.feedString(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// flushHex writes the bytes of the \xHH escapes read last, as they are when they form UTF-8
+// sequences and as code points otherwise
+func (r *streamRepairer) flushHex() {
+	if len(r.hexBytes) == 0 {
+		return
+	}
+
+	if utf8.Valid(r.hexBytes) {
+		r.writeEscaped(r.hexBytes)
+	} else {
+		var buf []byte
+		for _, c := range r.hexBytes {
+			buf = utf8.AppendRune(buf, rune(c))
+		}
+		r.writeEscaped(buf)
+	}
+	r.hexBytes = r.hexBytes[:0]
+}
+
+// writeEscaped writes the decoded string content b, escaping the quotes, backslashes and control characters
+func (r *streamRepairer) writeEscaped(b []byte) {
+	for _, c := range b {
+		switch {
+		case c == '"' || c == '\\':
+			r.w.WriteByte('\\')
+			r.w.WriteByte(c)
+		case c == '\n':
+			r.w.WriteString(`\n`)
+		case c == '\r':
+			r.w.WriteString(`\r`)
+		case c == '\t':
+			r.w.WriteString(`\t`)
+		case c < 0x20:
+			fmt.Fprintf(r.w, `\u%04x`, c)
+		default:
+			r.w.WriteByte(c)
+		}
+	}
+}
+
+// isHexDigit reports whether c is a hexadecimal digit
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// endToken writes the scalar token read so far
+func (r *streamRepairer) endToken() error {
+	token := string(r.token)
+	r.token = r.token[:0]
+	if token != "true" && token != "false" && token != "null" && !numberRe.MatchString(token) {
+		return ErrUnexpectedToken
+	}
+
+	r.w.WriteString(token)
+	r.expect = expectCommaOrEnd
+	return nil
+}
+
+// writeComma writes the comma held back before the member being started
+func (r *streamRepairer) writeComma() {
+	r.started = true
+	if r.comma {
+		r.w.WriteByte(',')
+		r.comma = false
+	}
+	r.first = false
+}
+
+// open enters the container opened by delim
+func (r *streamRepairer) open(delim byte) {
+	r.stack = append(r.stack, delim)
+	r.expect = r.memberStart()
+	r.first = true
+}
+
+// close writes the delimiter closing the current container, reporting whether it was the top-level one
+func (r *streamRepairer) close() bool {
+	r.w.WriteByte(byte(getReverseDelim(rune(r.stack[len(r.stack)-1]))))
+	r.stack = r.stack[:len(r.stack)-1]
+	r.expect = expectCommaOrEnd
+	r.first = false
+
+	return len(r.stack) == 0
+}
+
+// memberStart returns what starts a member of the current container
+func (r *streamRepairer) memberStart() int {
+	if r.stack[len(r.stack)-1] == '{' {
+		return expectKey
+	}
+	return expectValue
+}
+
+// completeMember gives null to a member cut after its key or its colon
+func (r *streamRepairer) completeMember() {
+	switch r.expect {
+	case expectColon:
+		r.w.WriteString(":null")
+	case expectValue:
+		r.w.WriteString("null")
+	}
+}
+
+// finish repairs the value cut by the end of the input and closes the open containers
+func (r *streamRepairer) finish() {
+	if r.inString {
+		r.flushHex()
+		r.w.WriteByte('"')
+		r.inString = false
+		if r.inKey {
+			r.expect = expectColon
+		} else {
+			r.expect = expectCommaOrEnd
+		}
+	}
+
+	if len(r.token) > 0 {
+		token := string(r.token)
+		for len(token) > 0 && !numberRe.MatchString(token) {
+			token = token[:len(token)-1]
+		}
+		if token == "" {
+			token = "null"
+		}
+		r.w.WriteString(token)
+		r.token = r.token[:0]
+		r.expect = expectCommaOrEnd
+	}
+
+	for len(r.stack) > 0 {
+		if r.expect != expectCommaOrEnd && !r.first && !r.comma {
+			r.completeMember()
+		}
+		r.comma = false
+		r.close()
+	}
+}
+
+// RepairedReader returns a reader of the document read from r repaired as by RepairStream,
+// suitable to hand to json.NewDecoder. What is repaired is readable as soon as it is read from r.
+// Closing the reader stops the repair
+func (p *JSONParser) RepairedReader(r io.Reader) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(p.RepairStream(pw, r))
+	}()
+
+	return pr
+}
@@ -0,0 +1,161 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestRepairStream(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		err             bool
+	}{
+		{
+			input:    "{\n  \"a\": [1, 2.5e3, true, null],\n  \"b\": {\"c\": \"你好\\n\\u4f60\"}\n} trailing",
+			expected: `{"a":[1,2.5e3,true,null],"b":{"c":"你好\n\u4f60"}}`,
+		},
+		{
+			input:    `{"a":[1,2,{"b":"x\u4f`,
+			expected: `{"a":[1,2,{"b":"x"}]}`,
+		},
+		{
+			input:    `{"a":"你` + "\xe5\xa5",
+			expected: `{"a":"你"}`,
+		},
+		{
+			input:    `[1,{"a":tr`,
+			expected: `[1,{"a":null}]`,
+		},
+		{
+			input:    `[1,12.`,
+			expected: `[1,12]`,
+		},
+		{
+			input:    `{"a":1,"b`,
+			expected: `{"a":1,"b":null}`,
+		},
+		{
+			input:    `{"a":1,"b":[1,],}`,
+			expected: `{"a":1,"b":[1]}`,
+		},
+		{
+			input: `{"a":,"b":1}`,
+			err:   true,
+		},
+		{
+			input: `{"a":1]`,
+			err:   true,
+		},
+		{
+			input: `"a"`,
+			err:   true,
+		},
+	}
+
+	for _, test := range tests {
+		var out bytes.Buffer
+		err := NewJSONParser(true).RepairStream(&out, iotest.OneByteReader(strings.NewReader(test.input)))
+		if test.err {
+			var perr *ParseError
+			require.True(t, errors.As(err, &perr), test.input)
+			continue
+		}
+
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, out.String())
+		require.True(t, json.Valid(out.Bytes()))
+	}
+
+	var out bytes.Buffer
+	require.Equal(t, ErrUnexpectedToken, NewJSONParser(true).RepairStream(&out, strings.NewReader("  ")))
+	require.NotNil(t, NewJSONParser(true).RepairStream(&out, iotest.ErrReader(io.ErrUnexpectedEOF)))
+}
+
+func TestRepairStreamEscapes(t *testing.T) {
+	tests := []struct {
+		input, expected string
+	}{
+		{input: `{"a":"x\qy"}`, expected: `{"a":"x\\qy"}`},
+		{input: `{"a":"x\u12"}`, expected: `{"a":"x\\u12"}`},
+		{input: `{"a":"caf\xc3\xa9 \x41"}`, expected: `{"a":"café A"}`},
+		{input: `{"a":"\xe9t\xe9"}`, expected: `{"a":"été"}`},
+		{input: "{\"a\":\"x\ty\n\"}", expected: `{"a":"x\ty\n"}`},
+	}
+
+	for _, test := range tests {
+		var out bytes.Buffer
+		err := NewJSONParser(true, WithLenient()).RepairStream(&out, iotest.OneByteReader(strings.NewReader(test.input)))
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, out.String(), test.input)
+		require.True(t, json.Valid(out.Bytes()), test.input)
+
+		data, err := NewJSONParser(true, WithLenient()).EnsureJSON(test.input)
+		require.Nil(t, err, test.input)
+		require.JSONEq(t, data, out.String(), test.input)
+	}
+
+	for _, input := range []string{`{"a":"x\qy"}`, `{"a":"x\u12"}`, "{\"a\":\"x\ty\"}"} {
+		var out bytes.Buffer
+		err := NewJSONParser(true).RepairStream(&out, strings.NewReader(input))
+		require.ErrorIs(t, err, ErrUnexpectedToken, input)
+	}
+}
+
+func TestRepairStreamLimits(t *testing.T) {
+	var out bytes.Buffer
+	err := NewJSONParser(true, WithMaxDepth(2)).RepairStream(&out, strings.NewReader(`{"a":[{"b":1}]}`))
+	var depth *DepthLimitError
+	require.ErrorAs(t, err, &depth)
+	require.Equal(t, 6, depth.Offset)
+
+	err = NewJSONParser(true, WithMaxInputBytes(8)).RepairStream(&out, strings.NewReader(`{"a":"0123456789"}`))
+	var size *SizeLimitError
+	require.ErrorAs(t, err, &size)
+	require.Equal(t, 8, size.Limit)
+}
+
+func TestRepairedReader(t *testing.T) {
+	src, w := io.Pipe()
+	r := NewJSONParser(true).RepairedReader(src)
+	defer r.Close()
+
+	go func() {
+		w.Write([]byte(`{"roles":[{"role_name":"我"},`))
+		w.Write([]byte(`{"role_name":"小`))
+		w.Close()
+	}()
+
+	var v struct {
+		Roles []struct {
+			RoleName string `json:"role_name"`
+		} `json:"roles"`
+	}
+	require.Nil(t, json.NewDecoder(r).Decode(&v))
+	require.Len(t, v.Roles, 2)
+	require.Equal(t, "小", v.Roles[1].RoleName)
+
+	src, w = io.Pipe()
+	r = NewJSONParser(true).RepairedReader(src)
+	w.Write([]byte(`[1,`))
+	buf := make([]byte, 8)
+	n, err := r.Read(buf)
+	require.Nil(t, err)
+	require.Equal(t, "[1", string(buf[:n]))
+	r.Close()
+	w.Close()
+
+	_, err = io.ReadAll(NewJSONParser(true).RepairedReader(strings.NewReader(`{"a":1]`)))
+	require.NotNil(t, err)
+}
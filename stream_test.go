@@ -0,0 +1,419 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestStreamDecoderFeed(t *testing.T) {
+	dec := NewStreamDecoder(NewJSONParser(true))
+
+	snapshot, err := dec.Feed([]byte(`{"question":"你好`))
+	require.Nil(t, err)
+	require.Equal(t, `{"question":null}`, snapshot.JSON)
+	require.False(t, snapshot.Complete)
+
+	_, err = dec.Feed([]byte(`","done":tr`))
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+	require.Equal(t, snapshot, dec.Snapshot())
+
+	snapshot, err = dec.Feed([]byte(`ue}`))
+	require.Nil(t, err)
+	require.Equal(t, `{"done":true,"question":"你好"}`, snapshot.JSON)
+	require.True(t, snapshot.Complete)
+	require.Equal(t, len(`{"question":"你好","done":true}`), snapshot.Bytes)
+}
+
+func TestStreamDecoderDumpState(t *testing.T) {
+	dec := NewStreamDecoder(NewJSONParser(true, WithLenient()))
+	_, err := dec.Feed([]byte(`{"score":.5,"scene_list":[{"chat_group":[{"content":"我盯着\"墨镜`))
+	require.Nil(t, err)
+
+	expected := `bytes consumed: 70
+snapshot bytes: 70
+complete: false
+open containers: object > array > object > array > object
+current path: scene_list.0.chat_group.0.content
+pending kind: string
+pending string: "我盯着\"墨镜"
+repairs: 7
+  offset 9: ".5" -> "0.5"
+  offset 52: "\"我盯着\\\"墨镜" -> "null"
+  offset 70: "" -> "}"
+  offset 70: "" -> "]"
+  offset 70: "" -> "}"
+  offset 70: "" -> "]"
+  offset 70: "" -> "}"
+`
+	require.Equal(t, expected, dec.DumpState())
+}
+
+func TestStreamDecoderHistory(t *testing.T) {
+	dec := NewStreamDecoder(NewJSONParser(true), WithHistory(3))
+	for _, chunk := range []string{`{"a":1,`, `"b":2,`, `"c":"x",`, `"d":"y"`} {
+		_, err := dec.Feed([]byte(chunk))
+		require.Nil(t, err)
+	}
+
+	history := dec.History()
+	require.Len(t, history, 3)
+	require.Equal(t, []int{2, 3, 4}, []int{history[0].Seq, history[1].Seq, history[2].Seq})
+	require.Equal(t, `{"a":1,"b":2}`, history[0].JSON)
+
+	snapshot, err := dec.Rewind(1)
+	require.Nil(t, err)
+	require.Equal(t, history[1].JSON, snapshot.JSON)
+	require.Equal(t, 3, snapshot.Seq)
+	require.Len(t, dec.History(), 2)
+
+	snapshot, err = dec.Feed([]byte(`"e":true}`))
+	require.Nil(t, err)
+	require.Equal(t, `{"a":1,"b":2,"c":"x","e":true}`, snapshot.JSON)
+	require.Equal(t, 4, snapshot.Seq)
+	require.Equal(t, []Snapshot{history[0], history[1], snapshot}, dec.History())
+
+	_, err = dec.Rewind(3)
+	require.Equal(t, ErrSnapshotNotFound, err)
+}
+
+func TestWatch(t *testing.T) {
+	dec := NewStreamDecoder(NewJSONParser(true))
+	question := Watch[string](dec, "question")
+	options := Watch[[]string](dec, "options")
+
+	var questions []string
+	var optionList [][]string
+	done := make(chan struct{})
+	go func() {
+		for q := range question {
+			questions = append(questions, q)
+		}
+		for o := range options {
+			optionList = append(optionList, o)
+		}
+		close(done)
+	}()
+
+	for _, chunk := range []string{`{"options":["接受`, `挑战"],"ques`, `tion":"如何`, `面对？","x":1`, `}`} {
+		_, err := dec.Feed([]byte(chunk))
+		require.Nil(t, err)
+	}
+
+	<-done
+	require.Equal(t, []string{"如何面对？"}, questions)
+	require.Equal(t, [][]string{{"接受挑战"}}, optionList)
+}
+
+func TestWatchNonStrict(t *testing.T) {
+	dec := NewStreamDecoder(NewJSONParser(false))
+	ch := Watch[string](dec, "scene\\.name")
+
+	var values []string
+	done := make(chan struct{})
+	go func() {
+		for v := range ch {
+			values = append(values, v)
+		}
+		close(done)
+	}()
+
+	for _, chunk := range []string{`{"scene.name":"夜`, `晚`, `"`, `,"a":1`} {
+		_, err := dec.Feed([]byte(chunk))
+		require.Nil(t, err)
+	}
+
+	<-done
+	require.Equal(t, []string{"夜", "夜晚", "夜晚"}, values)
+	dec.Close()
+}
+
+func TestDecodeBase64(t *testing.T) {
+	payload := []byte("partial json streaming base64 payload!")
+	encoded := base64.StdEncoding.EncodeToString(payload)
+
+	tests := []struct {
+		input  string
+		strict bool
+	}{
+		{
+			input:  `{"image":"` + encoded[:20] + `\n` + encoded[20:] + `","done":true}`,
+			strict: true,
+		},
+		{
+			input:  `{"image":"data:image/png;base64,` + strings.TrimRight(encoded, "=") + `"}`,
+			strict: false,
+		},
+	}
+
+	for _, test := range tests {
+		dec := NewStreamDecoder(NewJSONParser(test.strict))
+		var out bytes.Buffer
+		dec.DecodeBase64("image", base64.StdEncoding, &out)
+
+		for i := 0; i < len(test.input); i += 7 {
+			_, err := dec.Feed([]byte(test.input[i:min(i+7, len(test.input))]))
+			if errors.Is(err, ErrIncompleteNum) || errors.Is(err, ErrUnexpectedToken) {
+				continue
+			}
+			require.Nil(t, err)
+			require.True(t, bytes.HasPrefix(payload, out.Bytes()))
+		}
+		require.Equal(t, payload, out.Bytes())
+	}
+
+	dec := NewStreamDecoder(NewJSONParser(true))
+	dec.DecodeBase64("image", base64.StdEncoding, io.Discard)
+	_, err := dec.Feed([]byte(`{"image":"!!!!`))
+	require.NotNil(t, err)
+}
+
+func TestChunkString(t *testing.T) {
+	input := `{"content":"我盯着墨镜僵尸那张忧愁的脸，\"下一场是谁来的？\"","emotion":"疑惑"}`
+	dec := NewStreamDecoder(NewJSONParser(true))
+
+	var chunks []string
+	dec.ChunkString("content", 10, func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+
+	for i := 0; i < len(input); i += 5 {
+		_, err := dec.Feed([]byte(input[i:min(i+5, len(input))]))
+		require.Nil(t, err)
+	}
+
+	for _, chunk := range chunks {
+		require.LessOrEqual(t, len(chunk), 10)
+		require.True(t, utf8.ValidString(chunk), chunk)
+	}
+	require.Equal(t, `我盯着墨镜僵尸那张忧愁的脸，"下一场是谁来的？"`, strings.Join(chunks, ""))
+}
+
+func TestSubscribe(t *testing.T) {
+	dec := NewStreamDecoder(NewJSONParser(true))
+	ui := dec.Subscribe()
+	logger := dec.Subscribe()
+	abandoned := dec.Subscribe()
+	question := Watch[string](dec, "question")
+
+	chunks := []string{`{"question":"如何`, `面对？"`, `,"options":["接受"]`, `}`}
+	for _, chunk := range chunks {
+		_, err := dec.Feed([]byte(chunk))
+		require.Nil(t, err)
+	}
+	abandoned.Close()
+
+	var uiSnapshots, logSnapshots []Snapshot
+	for snapshot := range ui.C {
+		uiSnapshots = append(uiSnapshots, snapshot)
+	}
+	for snapshot := range logger.C {
+		logSnapshots = append(logSnapshots, snapshot)
+	}
+	for range abandoned.C {
+	}
+
+	require.Len(t, uiSnapshots, len(chunks))
+	require.Equal(t, uiSnapshots, logSnapshots)
+	require.True(t, uiSnapshots[len(uiSnapshots)-1].Complete)
+	require.Equal(t, "如何面对？", <-question)
+}
+
+func TestOnFirstValue(t *testing.T) {
+	tests := []struct {
+		path     string
+		chunks   []string
+		expected FirstValue
+	}{
+		{
+			chunks:   []string{`{"roles":[{"role_name":"我`, `"},{"role_name"`, `:"小僵尸"}]}`},
+			expected: FirstValue{Path: "roles.0.role_name", Value: "我", Bytes: 42, Elapsed: 2 * time.Second, SinceFirstChunk: time.Second},
+		},
+		{
+			path:     "question",
+			chunks:   []string{`{"roles":[{"role_name":"我"}],`, `"question":"如何`, `面对？"}`},
+			expected: FirstValue{Path: "question", Value: "如何面对？", Bytes: 60, Elapsed: 2 * time.Second, SinceFirstChunk: time.Second},
+		},
+	}
+
+	for _, test := range tests {
+		var events []FirstValue
+		dec := NewStreamDecoder(NewJSONParser(true), WithOnFirstValue(test.path, func(v FirstValue) {
+			events = append(events, v)
+		}))
+
+		clock := dec.created
+		dec.now = func() time.Time {
+			clock = clock.Add(time.Second)
+			return clock
+		}
+
+		for _, chunk := range test.chunks {
+			_, err := dec.Feed([]byte(chunk))
+			require.Nil(t, err)
+		}
+		require.Equal(t, []FirstValue{test.expected}, events)
+	}
+}
+
+func TestStallTimeout(t *testing.T) {
+	stalls := make(chan time.Duration, 10)
+	dec := NewStreamDecoder(NewJSONParser(true), WithStallTimeout(20*time.Millisecond, func(idle time.Duration) {
+		stalls <- idle
+	}))
+	defer dec.Close()
+
+	require.Equal(t, 20*time.Millisecond, <-stalls)
+	require.Equal(t, ErrStalled, dec.Err())
+
+	_, err := dec.Feed([]byte(`{"question":"如何`))
+	require.Nil(t, err)
+	require.Nil(t, dec.Err())
+
+	<-stalls
+	require.Equal(t, ErrStalled, dec.Err())
+
+	_, err = dec.Feed([]byte(`面对？"}`))
+	require.Nil(t, err)
+	require.Nil(t, dec.Err())
+
+	time.Sleep(50 * time.Millisecond)
+	require.Len(t, stalls, 0)
+	require.Nil(t, dec.Err())
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	input := `[{"role_name":"我","tags":["DJ"]},{"role_name":"墨镜僵尸"},"小僵尸",42]`
+	dec := NewStreamDecoder(NewJSONParser(false))
+	var out bytes.Buffer
+	dec.WriteNDJSON("", &out)
+
+	var lines []int
+	for i := 0; i < len(input); i += 6 {
+		_, err := dec.Feed([]byte(input[i:min(i+6, len(input))]))
+		require.Nil(t, err)
+		lines = append(lines, strings.Count(out.String(), "\n"))
+	}
+
+	require.Equal(t, "{\"role_name\":\"我\",\"tags\":[\"DJ\"]}\n{\"role_name\":\"墨镜僵尸\"}\n\"小僵尸\"\n42\n", out.String())
+	require.Equal(t, 0, lines[4])
+	require.Equal(t, 1, lines[6])
+
+	dec = NewStreamDecoder(NewJSONParser(true))
+	out.Reset()
+	dec.WriteNDJSON("roles", &out)
+	_, err := dec.Feed([]byte(`{"roles":[{"a":1},{"a":2},{"a":`))
+	require.Nil(t, err)
+	require.Equal(t, "{\"a\":1}\n{\"a\":2}\n", out.String())
+}
+
+func TestSnapshotCommitted(t *testing.T) {
+	tests := []struct {
+		parser    *JSONParser
+		chunks    []string
+		committed []int
+	}{
+		{
+			parser:    NewJSONParser(true),
+			chunks:    []string{`{"a":1,`, `"b":"x`, `yz","c":12`, `3,"d`, `":[1`, `0]}`},
+			committed: []int{7, 11, 21, 25, 30, 34},
+		},
+		{
+			parser:    NewJSONParser(false),
+			chunks:    []string{`{"question":"如何`, `"}`},
+			committed: []int{12, 21},
+		},
+	}
+
+	for _, test := range tests {
+		dec := NewStreamDecoder(test.parser)
+		var committed []int
+		for _, chunk := range test.chunks {
+			snapshot, err := dec.Feed([]byte(chunk))
+			require.Nil(t, err)
+			committed = append(committed, snapshot.Committed)
+		}
+		require.Equal(t, test.committed, committed)
+	}
+}
+
+func TestStream(t *testing.T) {
+	chunks := make(chan []byte, 4)
+	snapshots, errs := NewJSONParser(true).Stream(context.Background(), chunks)
+	for _, chunk := range []string{`{"ok":tr`, `ue,"items":[1`, `,2]}`, `ignored`} {
+		chunks <- []byte(chunk)
+	}
+
+	var got []string
+	for snapshot := range snapshots {
+		got = append(got, snapshot.JSON)
+	}
+	require.Equal(t, []string{`{"items":[1],"ok":true}`, `{"items":[1,2],"ok":true}`}, got)
+	require.Nil(t, <-errs)
+
+	chunks = make(chan []byte, 1)
+	snapshots, errs = NewJSONParser(true).Stream(context.Background(), chunks)
+	chunks <- []byte(`{"ok":tr`)
+	close(chunks)
+	_, open := <-snapshots
+	require.False(t, open)
+	require.ErrorIs(t, <-errs, ErrUnexpectedToken)
+
+	chunks = make(chan []byte, 1)
+	snapshots, errs = NewJSONParser(true, WithMaxInputBytes(4)).Stream(context.Background(), chunks)
+	chunks <- []byte(`{"items":[`)
+	_, open = <-snapshots
+	require.False(t, open)
+	var limit *SizeLimitError
+	require.ErrorAs(t, <-errs, &limit)
+
+	chunks = make(chan []byte, 2)
+	snapshots, errs = NewJSONParser(true, WithMaxDepth(2)).Stream(context.Background(), chunks)
+	chunks <- []byte(`[[[1`)
+	chunks <- []byte(`]]]`)
+	_, open = <-snapshots
+	require.False(t, open)
+	var depth *DepthLimitError
+	require.ErrorAs(t, <-errs, &depth)
+
+	chunks = make(chan []byte, 2)
+	snapshots, errs = NewJSONParserLevel(StrictnessReport).Stream(context.Background(), chunks)
+	chunks <- []byte(`{"a":1,`)
+	close(chunks)
+	require.Equal(t, `{"a":1}`, (<-snapshots).JSON)
+	_, open = <-snapshots
+	require.False(t, open)
+	require.ErrorIs(t, <-errs, ErrTruncated)
+}
+
+func TestStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	chunks := make(chan []byte, 1)
+	snapshots, errs := NewJSONParser(true).Stream(ctx, chunks)
+	chunks <- []byte(`{"a":1,`)
+	require.Equal(t, `{"a":1}`, (<-snapshots).JSON)
+
+	// the goroutine blocked sending the snapshot of the next chunk returns once canceled
+	chunks <- []byte(`"b":2,`)
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	require.ErrorIs(t, <-errs, context.Canceled)
+	for range snapshots {
+	}
+	_, open := <-errs
+	require.False(t, open)
+}
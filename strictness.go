@@ -0,0 +1,120 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrRepaired is reported in StrictnessReport mode for every repair applied to the input
+	ErrRepaired = errors.New("input repaired")
+	// ErrTruncated is reported in StrictnessReport mode when the input ended inside the document
+	ErrTruncated = errors.New("input truncated")
+)
+
+// Strictness is how much a parser repairs the input it is given
+type Strictness int
+
+const (
+	// StrictnessLoose repairs everything it can silently, keeping the strings and keys cut by the
+	// end of the input partially. It is the strictness of NewJSONParser(false)
+	StrictnessLoose Strictness = iota
+	// StrictnessStructural only completes the structure of a truncated input, dropping the values
+	// cut by its end. An input needing any other repair, including those of options such as
+	// WithLenient, fails with a *ParseError wrapping ErrUnexpectedToken at the first one
+	StrictnessStructural
+	// StrictnessReport repairs as NewJSONParser(true) does, but returns every repair and the
+	// completion of a truncated input as problems alongside the result
+	StrictnessReport
+	// StrictnessRFC rejects any input that is not a complete RFC 8259 document
+	StrictnessRFC
+)
+
+var strictnessNames = [...]string{
+	StrictnessLoose:      "loose",
+	StrictnessStructural: "structural",
+	StrictnessReport:     "report",
+	StrictnessRFC:        "rfc",
+}
+
+// String returns the name of the strictness
+func (s Strictness) String() string {
+	if s < 0 || int(s) >= len(strictnessNames) {
+		return "unknown"
+	}
+
+	return strictnessNames[s]
+}
+
+// keepsPartial reports whether the strings and keys cut by the end of the input are kept
+func (p *JSONParser) keepsPartial() bool {
+	return p.strictness == StrictnessLoose
+}
+
+// structuralError returns the error of a StrictnessStructural session that repaired more than the
+// structure of its input, nil if it did not
+func (p *JSONParser) structuralError() error {
+	if !p.structuralOnly {
+		return nil
+	}
+
+	for _, r := range p.state.repairs {
+		if !r.Kind.completes() {
+			return &ParseError{Offset: r.Offset, Err: ErrUnexpectedToken}
+		}
+	}
+	return nil
+}
+
+// checkRFC returns where s is not a valid RFC 8259 document in StrictnessRFC mode. When the parser
+// decodes valid JSON as json.Unmarshal does, the decoded document is returned as well so that s
+// is not parsed twice
+func (p *JSONParser) checkRFC(s string) (any, error) {
+	if p.strictness != StrictnessRFC {
+		return nil, nil
+	}
+
+	var data any
+	var raw json.RawMessage
+	target := any(&raw)
+	if p.decodesValidJSON() {
+		target = &data
+	}
+	err := json.Unmarshal([]byte(s), target)
+	if err == nil {
+		return data, nil
+	}
+
+	// the offset of a syntax error counts the byte it was found at, except at the end of the input
+	offset := len(s)
+	var serr *json.SyntaxError
+	if errors.As(err, &serr) && serr.Error() != "unexpected end of JSON input" {
+		offset = int(serr.Offset) - 1
+	}
+	return nil, &ParseError{Offset: offset, Err: ErrUnexpectedToken}
+}
+
+// report records in StrictnessReport mode the repairs and the completion of the session as problems
+func (p *JSONParser) report() {
+	if p.strictness != StrictnessReport {
+		return
+	}
+
+	st := p.state
+	for _, r := range st.normalized() {
+		st.problems = append(st.problems, &ParseError{
+			Offset: r.Offset,
+			Err:    fmt.Errorf("%w: %s %q -> %q", ErrRepaired, r.Kind, r.Original, r.Replacement),
+		})
+	}
+	if st.truncation != nil {
+		st.problems = append(st.problems, &ParseError{Offset: st.truncation.offset, Err: ErrTruncated})
+	}
+}
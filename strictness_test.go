@@ -0,0 +1,68 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStrictness(t *testing.T) {
+	tests := []struct {
+		level    Strictness
+		input    string
+		expected string
+		errs     []error
+	}{
+		{level: StrictnessLoose, input: `{"a":0x1F,"b":"如何`, expected: `{"a":31,"b":"如何"}`},
+		{level: StrictnessStructural, input: `{"a":0x1F,"b":"如何`, errs: []error{ErrUnexpectedToken}},
+		{level: StrictnessStructural, input: `{"a":[1,2],"b":"如何`, expected: `{"a":[1,2],"b":null}`},
+		{level: StrictnessStructural, input: `[1 2]`, errs: []error{ErrUnexpectedToken}},
+		{level: StrictnessStructural, input: `[1,]`, errs: []error{ErrUnexpectedToken}},
+		{level: StrictnessReport, input: `{"a":[1,],}`, expected: `{"a":[1]}`, errs: []error{ErrRepaired}},
+		{
+			level:    StrictnessReport,
+			input:    `{"a":0x1F,"b":"如何`,
+			expected: `{"a":31,"b":null}`,
+			errs:     []error{ErrRepaired, ErrTruncated},
+		},
+		{level: StrictnessReport, input: `{"a":1}`, expected: `{"a":1}`},
+		{level: StrictnessRFC, input: `{"a":0x1F}`, errs: []error{ErrUnexpectedToken}},
+		{level: StrictnessRFC, input: `{"a":[1,`, errs: []error{ErrUnexpectedToken}},
+		{level: StrictnessRFC, input: ` {"a":[1]} `, expected: `{"a":[1]}`},
+	}
+
+	for _, test := range tests {
+		data, err := NewJSONParserLevel(test.level, WithLenient()).EnsureJSON(test.input)
+		require.Equal(t, test.expected, data, test.level)
+		if len(test.errs) == 0 {
+			require.Nil(t, err, test.level)
+		}
+		for _, e := range test.errs {
+			require.ErrorIs(t, err, e, test.level)
+		}
+	}
+
+	_, err := NewJSONParserLevel(StrictnessReport, WithLenient()).EnsureJSON(`{"a":0x1F}`)
+	require.EqualError(t, err, `input repaired: radix number "0x1F" -> "31" at offset 5 (line 1, column 6)`)
+
+	_, err = NewJSONParserLevel(StrictnessReport).EnsureJSON(`[1,]`)
+	require.EqualError(t, err, `input repaired: dropped trailing comma "," -> "" at offset 2 (line 1, column 3)`)
+
+	_, err = NewJSONParserLevel(StrictnessRFC).EnsureJSON(`{"a":tru}`)
+	require.Equal(t, &ParseError{Offset: 8, Line: 1, Column: 9, Token: "}", Err: ErrUnexpectedToken}, err)
+
+	_, err = NewJSONParserLevel(StrictnessStructural, WithLenient()).EnsureJSON(`{"a":1,"b":0x1F}`)
+	require.Equal(t, &ParseError{Offset: 11, Line: 1, Column: 12, Token: "0x1F", Err: ErrUnexpectedToken}, err)
+
+	data, err := NewJSONParser(true, WithLenient()).EnsureJSON(`{"a":0x1F,"b":"如何`)
+	require.Nil(t, err)
+	require.Equal(t, `{"a":31,"b":null}`, data)
+
+	require.Equal(t, "report", StrictnessReport.String())
+	require.Equal(t, "unknown", Strictness(9).String())
+}
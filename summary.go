@@ -0,0 +1,73 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"slices"
+	"sort"
+)
+
+// Summary compares the final document of a stream with the last partial snapshot taken before it
+type Summary struct {
+	// Changed holds the paths of the values of the final document that differ from the last partial
+	// snapshot, including the values added, sorted. A path is empty for the whole document
+	Changed []string
+	// Finalized holds the paths of the values that were still being streamed in the last partial
+	// snapshot, from the outermost to the innermost
+	Finalized []string
+	// UnnecessaryRepairs holds the repairs of the last partial snapshot that the final document did not need
+	UnnecessaryRepairs []Repair
+	// Snapshots is the number of snapshots taken before the final one
+	Snapshots int
+}
+
+// WithOnSummary sets a function called once the document is complete with the summary of the
+// changes the last chunks made, which helps tuning the policies deciding when partial values
+// are shown or acted upon
+func WithOnSummary(fn func(s Summary)) StreamOption {
+	return func(d *StreamDecoder) {
+		var last Snapshot
+		var lastTr *truncation
+		var lastRepairs []Repair
+		snapshots := 0
+		d.watchers = append(d.watchers, &watcher{
+			notify: func(snapshot Snapshot, tr *truncation) (bool, error) {
+				if !snapshot.Complete {
+					last, lastTr, lastRepairs = snapshot, tr, d.repairs
+					snapshots++
+					return true, nil
+				}
+
+				s := Summary{Snapshots: snapshots}
+				diffPaths(last.Data, snapshot.Data, nil, &s.Changed)
+				sort.Strings(s.Changed)
+				if lastTr != nil {
+					for i := 0; i <= len(lastTr.path); i++ {
+						s.Finalized = append(s.Finalized, formatPath(lastTr.path[:i]))
+					}
+				}
+				for _, r := range lastRepairs {
+					if !r.Kind.completes() && !slices.Contains(d.repairs, r) {
+						s.UnnecessaryRepairs = append(s.UnnecessaryRepairs, r)
+					}
+				}
+
+				fn(s)
+				return false, nil
+			},
+			close: func() {},
+		})
+	}
+}
+
+// diffPaths appends the paths of the values of next found at path that differ from prev to paths.
+// The members and elements of containers present in both are compared one by one
+func diffPaths(prev, next any, path []any, paths *[]string) {
+	diffValues(prev, next, path, func(path []any, _, _ any, _ bool) {
+		*paths = append(*paths, formatPath(path))
+	})
+}
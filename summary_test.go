@@ -0,0 +1,47 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestOnSummary(t *testing.T) {
+	var summaries []Summary
+	dec := NewStreamDecoder(NewJSONParser(false, WithLenient()), WithOnSummary(func(s Summary) {
+		summaries = append(summaries, s)
+	}))
+
+	for _, chunk := range []string{`{"score":1.`, `,"roles":[{"name":"我"},{"name":"墨镜`, `僵尸"}],"done":true}`} {
+		_, err := dec.Feed([]byte(chunk))
+		require.Nil(t, err)
+	}
+
+	require.Equal(t, []Summary{{
+		Changed:            []string{"done", "roles.1.name"},
+		Finalized:          []string{"", "roles", "roles.1", "roles.1.name"},
+		UnnecessaryRepairs: nil,
+		Snapshots:          2,
+	}}, summaries)
+
+	summaries = nil
+	dec = NewStreamDecoder(NewJSONParser(false, WithLenient()), WithOnSummary(func(s Summary) {
+		summaries = append(summaries, s)
+	}))
+	for _, chunk := range []string{`{"score":1.`, `5}`} {
+		_, err := dec.Feed([]byte(chunk))
+		require.Nil(t, err)
+	}
+
+	require.Equal(t, []Summary{{
+		Changed:            []string{"score"},
+		Finalized:          []string{"", "score"},
+		UnnecessaryRepairs: []Repair{{Offset: 9, Original: "1.", Replacement: "1", Kind: RepairNormalizedNumber}},
+		Snapshots:          1,
+	}}, summaries)
+}
@@ -0,0 +1,43 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import "regexp"
+
+var (
+	// tagRe matches an HTML or XML tag such as <br>, </p> or <img src="x"/>
+	tagRe = regexp.MustCompile(`^</?[A-Za-z][A-Za-z0-9:_-]*(\s[^<>]*)?/?>`)
+	// partialTagRe matches a tag cut by the end of the input
+	partialTagRe = regexp.MustCompile(`^</?([A-Za-z][A-Za-z0-9:_-]*(\s[^<>]*)?/?)?$`)
+)
+
+// WithStripTags drops the HTML and XML tags found outside strings, such as <br> inserted
+// by a rendering layer between the tokens. Every dropped tag is reported as a repair
+func WithStripTags() ParserOption {
+	return func(p *JSONParser) {
+		p.stripTags = true
+	}
+}
+
+// skipTag skips the tag s starts with when tags are stripped. A tag cut by the end
+// of the input is skipped without being reported, as the rest of it may be streamed
+func (p *JSONParser) skipTag(s string) (string, bool) {
+	if !p.stripTags || len(s) == 0 || s[0] != '<' {
+		return s, false
+	}
+
+	if loc := tagRe.FindStringIndex(s); loc != nil {
+		p.repair(RepairDroppedTag, s, s[:loc[1]], "")
+		return s[loc[1]:], true
+	}
+
+	if partialTagRe.MatchString(s) {
+		return "", true
+	}
+
+	return s, false
+}
@@ -0,0 +1,52 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStripTags(t *testing.T) {
+	tests := []struct {
+		input, expected string
+		repairs         []Repair
+	}{
+		{
+			input:    `<p>{"a":"<b>x</b>",<br>"b":[1,<br/> 2]}</p>`,
+			expected: `{"a":"\u003cb\u003ex\u003c/b\u003e","b":[1,2]}`,
+			repairs: []Repair{
+				{Offset: 0, Original: "<p>", Replacement: "", Kind: RepairDroppedTag},
+				{Offset: 19, Original: "<br>", Replacement: "", Kind: RepairDroppedTag},
+				{Offset: 30, Original: "<br/>", Replacement: "", Kind: RepairDroppedTag},
+				{Offset: 39, Original: "</p>", Replacement: "", Kind: RepairDroppedTag},
+			},
+		},
+		{
+			input:    `{"a":1,<span class="x"`,
+			expected: `{"a":1}`,
+			repairs: []Repair{
+				{Offset: 22, Original: "", Replacement: "}", Kind: RepairClosedBracket},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var repairs []Repair
+		parser := NewJSONParser(true, WithStripTags(), WithOnRepair(func(r Repair) {
+			repairs = append(repairs, r)
+		}))
+
+		data, err := parser.FastEnsureJSON(test.input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+		require.Equal(t, test.repairs, repairs)
+	}
+
+	_, err := NewJSONParser(true).EnsureJSON(`{"a":1,<br>"b":2}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
@@ -0,0 +1,212 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// EnsureJSONFor returns a valid JSON string as EnsureJSON does, completed so that it unmarshals
+// into v without error: the members of the struct types of v missing from the document, such as
+// the fields not emitted yet, are added with the zero value of their type, and the values that do
+// not fit their field, such as a partial string where a number is expected, are replaced with it.
+// Slices and maps get empty values rather than null. Values unmarshaled by types implementing
+// json.Unmarshaler or encoding.TextUnmarshaler are kept as they are
+func (p *JSONParser) EnsureJSONFor(s string, v any) (string, error) {
+	sp := p.forTarget(v).session(s)
+	data, err := sp.run()
+	if data != nil {
+		data = fitType(data, reflect.TypeOf(v))
+	}
+
+	return sp.marshal(data, err)
+}
+
+// fitType returns v completed and corrected to unmarshal into the type t
+func fitType(v any, t reflect.Type) any {
+	for t != nil && t.Kind() == reflect.Pointer {
+		if v == nil {
+			return nil
+		}
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() == reflect.Interface || customUnmarshal(t) {
+		return v
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			obj = make(map[string]any)
+		}
+		eachField(t, func(name string, ft reflect.Type) {
+			key, ok := memberFor(obj, name)
+			if !ok {
+				obj[name] = zeroValue(ft)
+				return
+			}
+			obj[key] = fitType(obj[key], ft)
+		})
+		return obj
+	case reflect.Map:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return map[string]any{}
+		}
+		for key, val := range obj {
+			obj[key] = fitType(val, t.Elem())
+		}
+		return obj
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			// []byte is encoded as a base64 string
+			if s, ok := v.(string); ok {
+				return s
+			}
+			return zeroValue(t)
+		}
+		arr, ok := v.([]any)
+		if !ok {
+			return zeroValue(t)
+		}
+		for i, elem := range arr {
+			arr[i] = fitType(elem, t.Elem())
+		}
+		return arr
+	case reflect.String:
+		if _, ok := v.(string); ok {
+			return v
+		}
+	case reflect.Bool:
+		if _, ok := v.(bool); ok {
+			return v
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if fitsInteger(v, t) {
+			return v
+		}
+	case reflect.Float32, reflect.Float64:
+		switch v.(type) {
+		case float64, json.Number, *big.Int:
+			return v
+		}
+	default:
+		return v
+	}
+
+	return zeroValue(t)
+}
+
+// fitsInteger reports whether the number v unmarshals into the integer type t
+func fitsInteger(v any, t reflect.Type) bool {
+	var n *big.Int
+	switch v := v.(type) {
+	case float64:
+		if v != math.Trunc(v) {
+			return false
+		}
+		n, _ = big.NewFloat(v).Int(nil)
+	case json.Number:
+		f, _, err := big.ParseFloat(v.String(), 10, 0, big.ToNearestEven)
+		if err != nil || !f.IsInt() {
+			return false
+		}
+		n, _ = f.Int(nil)
+	case *big.Int:
+		n = v
+	default:
+		return false
+	}
+
+	bits := uint(t.Bits())
+	if t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64 {
+		return n.Sign() >= 0 && n.BitLen() <= int(bits)
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), bits-1)
+	return n.Cmp(limit) < 0 && n.Cmp(limit.Neg(limit)) >= 0
+}
+
+// zeroValue returns the parsed form of the JSON encoding of the zero value of t, empty values standing for slices and maps
+func zeroValue(t reflect.Type) any {
+	switch t.Kind() {
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Uint8 {
+			return []any{}
+		}
+	case reflect.Map:
+		return map[string]any{}
+	case reflect.Pointer, reflect.Interface:
+		return nil
+	}
+
+	b, err := json.Marshal(reflect.Zero(t).Interface())
+	if err != nil {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil
+	}
+
+	return fitType(v, t)
+}
+
+// customUnmarshal reports whether values of type t are unmarshaled by their own method
+func customUnmarshal(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return pt.Implements(jsonUnmarshalerType) || pt.Implements(textUnmarshalerType)
+}
+
+// memberFor returns the member of obj encoding the field name, matching names as encoding/json does
+func memberFor(obj map[string]any, name string) (string, bool) {
+	if _, ok := obj[name]; ok {
+		return name, true
+	}
+	for key := range obj {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+// eachField calls fn with the member name and the type of every field of the struct type t
+// encoded by encoding/json, including the fields promoted from embedded structs
+func eachField(t reflect.Type, fn func(name string, ft reflect.Type)) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			eachField(ft, fn)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		fn(name, f.Type)
+	}
+}
@@ -0,0 +1,91 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type targetItem struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type targetBase struct {
+	ID string `json:"id"`
+}
+
+type targetReply struct {
+	targetBase
+	Title   string            `json:"title"`
+	Score   float64           `json:"score"`
+	Done    bool              `json:"done"`
+	Tags    []string          `json:"tags"`
+	Meta    map[string]int    `json:"meta"`
+	Items   []targetItem      `json:"items"`
+	Parent  *targetItem       `json:"parent"`
+	Extra   any               `json:"extra"`
+	At      time.Time         `json:"at"`
+	Skipped string            `json:"-"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+func TestEnsureJSONFor(t *testing.T) {
+	parser := NewJSONParser(false)
+
+	tests := []struct {
+		input, expected string
+		target          any
+	}{
+		{
+			input:    `{"title": "Hel`,
+			target:   &targetReply{},
+			expected: `{"at":"0001-01-01T00:00:00Z","done":false,"extra":null,"id":"","items":[],"labels":{},"meta":{},"parent":null,"score":0,"tags":[],"title":"Hel"}`,
+		},
+		{
+			input:    `{"id": 7, "score": "high", "done": 1, "tags": "a", "items": [{"name": "x", "count": 1.5}, {"count": 2`,
+			target:   &targetReply{},
+			expected: `{"at":"0001-01-01T00:00:00Z","done":false,"extra":null,"id":"","items":[{"count":0,"name":"x"},{"count":2,"name":""}],"labels":{},"meta":{},"parent":null,"score":0,"tags":[],"title":""}`,
+		},
+		{
+			input:    `{"Title": "a", "Parent": {"Name": "p"`,
+			target:   &targetReply{},
+			expected: `{"Parent":{"Name":"p","count":0},"Title":"a","at":"0001-01-01T00:00:00Z","done":false,"extra":null,"id":"","items":[],"labels":{},"meta":{},"score":0,"tags":[]}`,
+		},
+		{
+			input:    `[300, -128, 12`,
+			target:   &[]int8{},
+			expected: `[0,-128,12]`,
+		},
+		{
+			input:    `{"a": 1, "b": "2", "c": `,
+			target:   &map[string]int{},
+			expected: `{"a":1,"b":0,"c":0}`,
+		},
+		{
+			input:    `[1, 2`,
+			target:   &targetItem{},
+			expected: `{"count":0,"name":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		data, err := parser.EnsureJSONFor(test.input, test.target)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, data, test.input)
+		require.Nil(t, json.Unmarshal([]byte(data), test.target), test.input)
+	}
+}
+
+func TestEnsureJSONForStrictness(t *testing.T) {
+	data, err := NewJSONParser(true).EnsureJSONFor(`{"name": "ab`, &targetItem{})
+	require.Nil(t, err)
+	require.Equal(t, `{"count":0,"name":""}`, data)
+}
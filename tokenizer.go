@@ -0,0 +1,305 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// TokenKind is the kind of a token emitted by a Tokenizer
+type TokenKind int
+
+const (
+	// TokenObjectStart is the { starting an object
+	TokenObjectStart TokenKind = iota
+	// TokenObjectEnd is the } ending an object
+	TokenObjectEnd
+	// TokenArrayStart is the [ starting an array
+	TokenArrayStart
+	// TokenArrayEnd is the ] ending an array
+	TokenArrayEnd
+	// TokenKey is a member name
+	TokenKey
+	// TokenKeyPartial is the received part of a member name cut by the end of the input
+	TokenKeyPartial
+	// TokenString is a string value
+	TokenString
+	// TokenStringPartial is the received part of a string value cut by the end of the input
+	TokenStringPartial
+	// TokenNumber is a number
+	TokenNumber
+	// TokenNumberPartial is a number ending the input, which more input may extend
+	TokenNumberPartial
+	// TokenBool is true or false
+	TokenBool
+	// TokenNull is null
+	TokenNull
+)
+
+var tokenKindNames = [...]string{
+	TokenObjectStart:   "ObjectStart",
+	TokenObjectEnd:     "ObjectEnd",
+	TokenArrayStart:    "ArrayStart",
+	TokenArrayEnd:      "ArrayEnd",
+	TokenKey:           "Key",
+	TokenKeyPartial:    "KeyPartial",
+	TokenString:        "String",
+	TokenStringPartial: "StringPartial",
+	TokenNumber:        "Number",
+	TokenNumberPartial: "NumberPartial",
+	TokenBool:          "Bool",
+	TokenNull:          "Null",
+}
+
+// String returns the name of the token kind, e.g. ObjectStart
+func (k TokenKind) String() string {
+	if k < 0 || int(k) >= len(tokenKindNames) {
+		return "unknown"
+	}
+
+	return tokenKindNames[k]
+}
+
+// Token is a token of a JSON document
+type Token struct {
+	// Kind is the kind of the token
+	Kind TokenKind
+	// Value is the decoded member name or string for keys and strings, the literal of numbers as a
+	// json.Number, the value of booleans and nil for the other tokens. The literal of a partial
+	// number may not be a valid number yet, as in 1e
+	Value any
+	// Offset is the byte offset of the token in the input
+	Offset int
+}
+
+// Tokenizer splits a JSON document, possibly cut by the end of the input, into tokens without
+// building its values, so that applications can materialize it their own way. The values cut by
+// the end of the input are emitted as partial tokens, except cut literals such as tr which end the
+// tokens. The containers left open are not closed: Depth tells how many remain
+type Tokenizer struct {
+	input    string
+	pos      int
+	expect   int
+	first    bool
+	done     bool
+	stack    []byte
+	err      error
+	complete bool
+}
+
+// NewTokenizer returns a tokenizer of the document s
+func NewTokenizer(s string) *Tokenizer {
+	return &Tokenizer{input: s}
+}
+
+// Next returns the next token of the document. It returns io.EOF once the input is exhausted, and a
+// *ParseError wrapping ErrUnexpectedToken if the input is not JSON, every later call returning it too
+func (t *Tokenizer) Next() (Token, error) {
+	if t.err != nil {
+		return Token{}, t.err
+	}
+
+	tok, err := t.next()
+	if err != nil {
+		t.err = locate(err, t.input)
+	}
+
+	return tok, t.err
+}
+
+// Depth returns the number of the objects and arrays started and not ended yet
+func (t *Tokenizer) Depth() int {
+	return len(t.stack)
+}
+
+// Complete reports whether the whole document was read, the tokenizer having returned io.EOF
+// after the end of its top-level value
+func (t *Tokenizer) Complete() bool {
+	return t.complete
+}
+
+// next returns the next token, io.EOF at the end of the input
+func (t *Tokenizer) next() (Token, error) {
+	t.skipSpace()
+	if t.pos == len(t.input) {
+		t.complete = t.done
+		return Token{}, io.EOF
+	}
+	if t.done {
+		return Token{}, t.unexpected()
+	}
+
+	c := t.input[t.pos]
+	switch t.expect {
+	case expectColon:
+		if c != ':' {
+			return Token{}, t.unexpected()
+		}
+		t.pos++
+		t.expect = expectValue
+		return t.next()
+	case expectCommaOrEnd:
+		switch c {
+		case ',':
+			t.pos++
+			t.expect = expectValue
+			if t.stack[len(t.stack)-1] == '{' {
+				t.expect = expectKey
+			}
+			return t.next()
+		case '}', ']':
+			return t.end(c)
+		}
+		return Token{}, t.unexpected()
+	case expectKey:
+		if c == '}' && t.first {
+			return t.end(c)
+		}
+		if c != '"' {
+			return Token{}, t.unexpected()
+		}
+		t.first = false
+		t.expect = expectColon
+		return t.string(TokenKey, TokenKeyPartial)
+	}
+
+	if c == ']' && t.first {
+		return t.end(c)
+	}
+
+	t.first = false
+	start := t.pos
+	switch c {
+	case '{', '[':
+		t.pos++
+		t.stack = append(t.stack, c)
+		t.first = true
+		if c == '{' {
+			t.expect = expectKey
+			return Token{Kind: TokenObjectStart, Offset: start}, nil
+		}
+		t.expect = expectValue
+		return Token{Kind: TokenArrayStart, Offset: start}, nil
+	case '"':
+		t.afterValue()
+		return t.string(TokenString, TokenStringPartial)
+	case 't':
+		return t.literal("true", TokenBool, true)
+	case 'f':
+		return t.literal("false", TokenBool, false)
+	case 'n':
+		return t.literal("null", TokenNull, nil)
+	}
+	if c == '-' || c >= '0' && c <= '9' {
+		return t.number()
+	}
+
+	return Token{}, t.unexpected()
+}
+
+// skipSpace skips the whitespace at the current position
+func (t *Tokenizer) skipSpace() {
+	for t.pos < len(t.input) && strings.IndexByte(" \t\r\n", t.input[t.pos]) >= 0 {
+		t.pos++
+	}
+}
+
+// unexpected returns the error reporting the token at the current position as unexpected
+func (t *Tokenizer) unexpected() error {
+	return &ParseError{Offset: t.pos, Err: ErrUnexpectedToken}
+}
+
+// afterValue sets the state following a value
+func (t *Tokenizer) afterValue() {
+	t.first = false
+	t.expect = expectCommaOrEnd
+	t.done = len(t.stack) == 0
+}
+
+// end returns the token of the delimiter c ending the innermost container
+func (t *Tokenizer) end(c byte) (Token, error) {
+	open := t.stack[len(t.stack)-1]
+	if c == '}' && open != '{' || c == ']' && open != '[' {
+		return Token{}, t.unexpected()
+	}
+
+	start := t.pos
+	t.pos++
+	t.stack = t.stack[:len(t.stack)-1]
+	t.afterValue()
+	if c == '}' {
+		return Token{Kind: TokenObjectEnd, Offset: start}, nil
+	}
+
+	return Token{Kind: TokenArrayEnd, Offset: start}, nil
+}
+
+// string returns the token of the string at the current position, of the kind partial if the
+// input ends inside it
+func (t *Tokenizer) string(kind, partial TokenKind) (Token, error) {
+	start := t.pos
+	s := t.input[start:]
+	n := stringEnd(s)
+	if n < 0 {
+		t.pos = len(t.input)
+		t.done = false
+		return Token{Kind: partial, Value: partialString(s[1:]), Offset: start}, nil
+	}
+
+	value := s[1:n]
+	if strings.IndexByte(value, '\\') >= 0 || strings.ContainsFunc(value, func(r rune) bool { return r < 0x20 }) {
+		if err := json.Unmarshal([]byte(s[:n+1]), &value); err != nil {
+			return Token{}, t.unexpected()
+		}
+	}
+
+	t.pos += n + 1
+	return Token{Kind: kind, Value: value, Offset: start}, nil
+}
+
+// literal returns the token of the literal lit of the given kind and value at the current position.
+// A literal cut by the end of the input ends the tokens
+func (t *Tokenizer) literal(lit string, kind TokenKind, value any) (Token, error) {
+	s := t.input[t.pos:]
+	if !strings.HasPrefix(s, lit) {
+		if strings.HasPrefix(lit, s) {
+			t.pos = len(t.input)
+			return Token{}, io.EOF
+		}
+		return Token{}, t.unexpected()
+	}
+
+	start := t.pos
+	t.pos += len(lit)
+	t.afterValue()
+	return Token{Kind: kind, Value: value, Offset: start}, nil
+}
+
+// number returns the token of the number at the current position, partial if it ends the input
+func (t *Tokenizer) number() (Token, error) {
+	start := t.pos
+	end := start
+	for end < len(t.input) && strings.IndexByte("+-.0123456789eE", t.input[end]) >= 0 {
+		end++
+	}
+
+	lit := t.input[start:end]
+	t.pos = end
+	t.afterValue()
+	if end == len(t.input) {
+		t.done = false
+		return Token{Kind: TokenNumberPartial, Value: json.Number(lit), Offset: start}, nil
+	}
+	if !numberRe.MatchString(lit) {
+		t.pos = start
+		return Token{}, t.unexpected()
+	}
+
+	return Token{Kind: TokenNumber, Value: json.Number(lit), Offset: start}, nil
+}
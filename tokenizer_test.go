@@ -0,0 +1,120 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+)
+
+// tokens returns the tokens of tz up to the end of the input or the first error
+func tokens(tz *Tokenizer) ([]Token, error) {
+	var toks []Token
+	for {
+		tok, err := tz.Next()
+		if err == io.EOF {
+			return toks, nil
+		}
+		if err != nil {
+			return toks, err
+		}
+		toks = append(toks, tok)
+	}
+}
+
+func TestTokenizer(t *testing.T) {
+	tz := NewTokenizer(`{"a": [1, true, null], "b\n": {}, "c": "x\"y`)
+	toks, err := tokens(tz)
+	require.Nil(t, err)
+	require.Equal(t, []Token{
+		{Kind: TokenObjectStart, Offset: 0},
+		{Kind: TokenKey, Value: "a", Offset: 1},
+		{Kind: TokenArrayStart, Offset: 6},
+		{Kind: TokenNumber, Value: json.Number("1"), Offset: 7},
+		{Kind: TokenBool, Value: true, Offset: 10},
+		{Kind: TokenNull, Offset: 16},
+		{Kind: TokenArrayEnd, Offset: 20},
+		{Kind: TokenKey, Value: "b\n", Offset: 23},
+		{Kind: TokenObjectStart, Offset: 30},
+		{Kind: TokenObjectEnd, Offset: 31},
+		{Kind: TokenKey, Value: "c", Offset: 34},
+		{Kind: TokenStringPartial, Value: `x"y`, Offset: 39},
+	}, toks)
+	require.Equal(t, 1, tz.Depth())
+	require.False(t, tz.Complete())
+}
+
+func TestTokenizerPartial(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []Token
+		depth    int
+		complete bool
+	}{
+		{
+			input:    `[12`,
+			expected: []Token{{Kind: TokenArrayStart}, {Kind: TokenNumberPartial, Value: json.Number("12"), Offset: 1}},
+			depth:    1,
+		},
+		{
+			input:    `{"ke`,
+			expected: []Token{{Kind: TokenObjectStart}, {Kind: TokenKeyPartial, Value: "ke", Offset: 1}},
+			depth:    1,
+		},
+		{
+			input:    `{"a": tr`,
+			expected: []Token{{Kind: TokenObjectStart}, {Kind: TokenKey, Value: "a", Offset: 1}},
+			depth:    1,
+		},
+		{
+			input:    `"a\u00`,
+			expected: []Token{{Kind: TokenStringPartial, Value: "a"}},
+		},
+		{
+			input:    ` [ ] `,
+			expected: []Token{{Kind: TokenArrayStart, Offset: 1}, {Kind: TokenArrayEnd, Offset: 3}},
+			complete: true,
+		},
+	}
+
+	for _, test := range tests {
+		tz := NewTokenizer(test.input)
+		toks, err := tokens(tz)
+		require.Nil(t, err, test.input)
+		require.Equal(t, test.expected, toks, test.input)
+		require.Equal(t, test.depth, tz.Depth(), test.input)
+		require.Equal(t, test.complete, tz.Complete(), test.input)
+	}
+}
+
+func TestTokenizerError(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+	}{
+		{input: `{"a" 1}`, offset: 5},
+		{input: `[1,]`, offset: 3},
+		{input: `[1}`, offset: 2},
+		{input: `{"a": 01, "b": 2}`, offset: 6},
+		{input: `{} x`, offset: 3},
+		{input: `[nul]`, offset: 1},
+	}
+
+	for _, test := range tests {
+		tz := NewTokenizer(test.input)
+		_, err := tokens(tz)
+		var perr *ParseError
+		require.ErrorAs(t, err, &perr, test.input)
+		require.ErrorIs(t, err, ErrUnexpectedToken, test.input)
+		require.Equal(t, test.offset, perr.Offset, test.input)
+
+		_, again := tz.Next()
+		require.Equal(t, err, again, test.input)
+	}
+}
@@ -0,0 +1,172 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import "sort"
+
+// ToolCall is the repaired state of the arguments of a streamed tool call
+type ToolCall struct {
+	// Index is the index of the call in the response
+	Index int
+	// ID and Name are the id of the call and the name of the tool, when streamed
+	ID, Name string
+	// Snapshot is the repaired snapshot of the arguments received so far
+	Snapshot Snapshot
+}
+
+// ToolCallDemux splits the tool calls of a model response, keeping an independent repair
+// state per call, and reports every call once its arguments are complete
+type ToolCallDemux struct {
+	parser     *JSONParser
+	calls      map[int]*toolCall
+	onComplete func(ToolCall)
+
+	// state of the values split by FeedText
+	next     int
+	depth    int
+	inString bool
+	escape   bool
+}
+
+type toolCall struct {
+	ToolCall
+	dec      *StreamDecoder
+	reported bool
+}
+
+// NewToolCallDemux creates a ToolCallDemux repairing the arguments with p and calling onComplete,
+// if not nil, once for every call whose arguments are complete
+func NewToolCallDemux(p *JSONParser, onComplete func(ToolCall)) *ToolCallDemux {
+	return &ToolCallDemux{parser: p, calls: make(map[int]*toolCall), onComplete: onComplete}
+}
+
+// Feed appends a fragment of the arguments of the call at index, as streamed in the tool call deltas
+// of chat completions in which the fragments of several calls may be interleaved. The id and the
+// name are recorded when not empty, as they are usually only sent with the first fragment. The
+// problems skipped over in lenient mode are returned alongside the updated call
+func (d *ToolCallDemux) Feed(index int, id, name, fragment string) (ToolCall, error) {
+	call := d.call(index)
+	if id != "" {
+		call.ID = id
+	}
+	if name != "" {
+		call.Name = name
+	}
+	if fragment == "" {
+		return call.ToolCall, nil
+	}
+
+	fed := call.dec.seq + 1
+	snapshot, err := call.dec.Feed([]byte(fragment))
+	if snapshot.Seq != fed {
+		return call.ToolCall, err
+	}
+	call.Snapshot = snapshot
+	d.report(call)
+
+	return call.ToolCall, err
+}
+
+// FeedText appends a chunk of a text in which the arguments of the calls are streamed back to back,
+// as in {"city":"Paris"}{"city":"Rome"}, each top-level value being the arguments of the next call.
+// Whitespace and commas between the values are ignored
+func (d *ToolCallDemux) FeedText(text string) error {
+	start := 0
+	flush := func(end int) error {
+		if end > start {
+			if _, err := d.Feed(d.next, "", "", text[start:end]); err != nil {
+				return err
+			}
+		}
+		start = end
+		return nil
+	}
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case d.inString:
+			switch {
+			case d.escape:
+				d.escape = false
+			case c == '\\':
+				d.escape = true
+			case c == '"':
+				d.inString = false
+			}
+		case c == '"':
+			d.inString = true
+		case c == '{' || c == '[':
+			if d.depth == 0 {
+				start = i
+			}
+			d.depth++
+		case c == '}' || c == ']':
+			d.depth--
+			if d.depth == 0 {
+				if err := flush(i + 1); err != nil {
+					return err
+				}
+				d.next++
+			}
+		case d.depth == 0:
+			// whitespace and separators between the values
+			start = i + 1
+		}
+	}
+
+	if d.depth > 0 {
+		return flush(len(text))
+	}
+
+	return nil
+}
+
+// Call returns the call at index and whether any delta of it was seen
+func (d *ToolCallDemux) Call(index int) (ToolCall, bool) {
+	call, ok := d.calls[index]
+	if !ok {
+		return ToolCall{}, false
+	}
+
+	return call.ToolCall, true
+}
+
+// Calls returns the calls seen so far, sorted by index
+func (d *ToolCallDemux) Calls() []ToolCall {
+	calls := make([]ToolCall, 0, len(d.calls))
+	for _, call := range d.calls {
+		calls = append(calls, call.ToolCall)
+	}
+	sort.Slice(calls, func(i, j int) bool { return calls[i].Index < calls[j].Index })
+
+	return calls
+}
+
+// call returns the call at index, creating it on its first fragment
+func (d *ToolCallDemux) call(index int) *toolCall {
+	call, ok := d.calls[index]
+	if !ok {
+		call = &toolCall{ToolCall: ToolCall{Index: index}, dec: NewStreamDecoder(d.parser)}
+		d.calls[index] = call
+	}
+
+	return call
+}
+
+// report calls onComplete the first time the arguments of call are complete
+func (d *ToolCallDemux) report(call *toolCall) {
+	if call.reported || !call.Snapshot.Complete {
+		return
+	}
+
+	call.reported = true
+	call.dec.Close()
+	if d.onComplete != nil {
+		d.onComplete(call.ToolCall)
+	}
+}
@@ -0,0 +1,65 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestToolCallDemux(t *testing.T) {
+	var completed []string
+	demux := NewToolCallDemux(NewJSONParser(false), func(call ToolCall) {
+		completed = append(completed, call.Name+" "+call.Snapshot.JSON)
+	})
+
+	deltas := []struct {
+		index              int
+		id, name, fragment string
+	}{
+		{0, "call_1", "get_weather", ""},
+		{0, "", "", `{"city":"Par`},
+		{1, "call_2", "get_time", `{"tz":"Europe/`},
+		{0, "", "", `is"}`},
+		{1, "", "", `Rome"}`},
+	}
+	for _, delta := range deltas {
+		_, err := demux.Feed(delta.index, delta.id, delta.name, delta.fragment)
+		require.Nil(t, err)
+	}
+
+	require.Equal(t, []string{`get_weather {"city":"Paris"}`, `get_time {"tz":"Europe/Rome"}`}, completed)
+	calls := demux.Calls()
+	require.Len(t, calls, 2)
+	require.Equal(t, "call_2", calls[1].ID)
+
+	completed = nil
+	demux = NewToolCallDemux(NewJSONParser(false), func(call ToolCall) {
+		completed = append(completed, call.Snapshot.JSON)
+	})
+	for _, chunk := range []string{`{"a":"}{"}`, `{"b":[1,`, `2]}`, "\n", `{"c":`} {
+		require.Nil(t, demux.FeedText(chunk))
+	}
+
+	require.Equal(t, []string{`{"a":"}{"}`, `{"b":[1,2]}`}, completed)
+	calls = demux.Calls()
+	require.Len(t, calls, 3)
+	require.Equal(t, `{"c":null}`, calls[2].Snapshot.JSON)
+	require.False(t, calls[2].Snapshot.Complete)
+
+	completed = nil
+	demux = NewToolCallDemux(NewJSONParser(false, WithLenient()), func(call ToolCall) {
+		completed = append(completed, call.Snapshot.JSON)
+	})
+	call, err := demux.Feed(0, "call_1", "get_weather", `{"city":"Paris","x":bad,`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+	require.Equal(t, `{"city":"Paris"}`, call.Snapshot.JSON)
+	call, err = demux.Feed(0, "", "", `"unit":"c"}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+	require.Equal(t, `{"city":"Paris","unit":"c"}`, call.Snapshot.JSON)
+	require.Equal(t, []string{`{"city":"Paris","unit":"c"}`}, completed)
+}
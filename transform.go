@@ -0,0 +1,124 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"strconv"
+)
+
+// ErrPathNotFound is returned when a path does not lead to a value of the document
+var ErrPathNotFound = errors.New("path not found")
+
+// Document is a repaired document handed to the transforms set with WithTransforms
+type Document struct {
+	// Value is the repaired value, made of map[string]any, []any and scalars, which transforms may change or replace
+	Value any
+	// Complete reports whether the input held the whole document
+	Complete bool
+	// Repairs holds the repairs applied to the input
+	Repairs []Repair
+}
+
+// WithTransforms sets functions run in order on the repaired value before it is returned or
+// marshaled, so that normalization such as trimming, enum mapping or unit conversion needs no
+// second parse. They also run on the value parsed up to the failure point that is returned along
+// with a parse error. The first error returned by a transform fails the parse
+func WithTransforms(fn ...func(doc *Document) error) ParserOption {
+	return func(p *JSONParser) {
+		p.transforms = append(p.transforms, fn...)
+	}
+}
+
+// Get returns the value at path in the dotted syntax, and whether it exists
+func (d *Document) Get(path string) (any, bool) {
+	return lookupPath(d.Value, parsePath(path))
+}
+
+// Set sets the value at path in the dotted syntax. The parent of the value must exist,
+// a missing member being added to its object
+func (d *Document) Set(path string, v any) error {
+	segs := parsePath(path)
+	if len(segs) == 0 {
+		d.Value = v
+		return nil
+	}
+
+	parent, ok := lookupPath(d.Value, segs[:len(segs)-1])
+	if !ok {
+		return ErrPathNotFound
+	}
+
+	last := segs[len(segs)-1]
+	switch parent := parent.(type) {
+	case map[string]any:
+		parent[last] = v
+	case []any:
+		i, err := strconv.Atoi(last)
+		if err != nil || i < 0 || i >= len(parent) {
+			return ErrPathNotFound
+		}
+		parent[i] = v
+	default:
+		return ErrPathNotFound
+	}
+
+	return nil
+}
+
+// Walk calls fn for every value of the document, parents before their children, replacing
+// each value with the value fn returns. The first error returned by fn stops the walk
+func (d *Document) Walk(fn func(path string, v any) (any, error)) error {
+	v, err := walk(d.Value, nil, fn)
+	if err != nil {
+		return err
+	}
+
+	d.Value = v
+	return nil
+}
+
+// walk replaces the value v found at path and its children with the values fn returns
+func walk(v any, path []any, fn func(path string, v any) (any, error)) (any, error) {
+	v, err := fn(formatPath(path), v)
+	if err != nil {
+		return nil, err
+	}
+
+	switch val := v.(type) {
+	case map[string]any:
+		for key, child := range val {
+			if val[key], err = walk(child, append(path[:len(path):len(path)], key), fn); err != nil {
+				return nil, err
+			}
+		}
+	case []any:
+		for i, child := range val {
+			if val[i], err = walk(child, append(path[:len(path):len(path)], i), fn); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return v, nil
+}
+
+// transform runs the transforms on the value data repaired by the session
+func (p *JSONParser) transform(data any) (any, error) {
+	if len(p.transforms) == 0 {
+		return data, nil
+	}
+
+	doc := &Document{Value: data, Complete: p.state.truncation == nil, Repairs: p.state.repairs}
+	for _, fn := range p.transforms {
+		if err := fn(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc.Value, nil
+}
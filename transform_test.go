@@ -0,0 +1,70 @@
+package partialjson
+
+/*
+ * Copyright (c) 2025 shado1111w.
+ * Licensed under the MIT License.
+ * See LICENSE file in the project root for full license information.
+ */
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestTransforms(t *testing.T) {
+	trim := func(doc *Document) error {
+		return doc.Walk(func(_ string, v any) (any, error) {
+			if s, ok := v.(string); ok {
+				return strings.TrimSpace(s), nil
+			}
+			return v, nil
+		})
+	}
+	emotion := func(doc *Document) error {
+		if v, ok := doc.Get("emotion"); ok && v == "疑惑" {
+			return doc.Set("emotion", "confused")
+		}
+		return nil
+	}
+	celsius := func(doc *Document) error {
+		if f, ok := doc.Get("temp_f"); ok && doc.Complete {
+			return doc.Set("temp_c", (f.(float64)-32)*5/9)
+		}
+		return nil
+	}
+
+	parser := NewJSONParser(false, WithTransforms(trim, emotion, celsius))
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: `{"emotion":" 疑惑 ","roles":[" 我 "],"temp_f":212}`, expected: `{"emotion":"confused","roles":["我"],"temp_c":100,"temp_f":212}`},
+		{input: `{"emotion":" 疑惑 ","temp_f":212,"roles":["我 `, expected: `{"emotion":"confused","roles":["我"],"temp_f":212}`},
+	}
+
+	for _, test := range tests {
+		data, err := parser.EnsureJSON(test.input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+
+		data, err = parser.FastEnsureJSON(test.input)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, data)
+	}
+
+	errStop := errors.New("stop")
+	_, err := NewJSONParser(true, WithTransforms(func(*Document) error { return errStop })).EnsureJSON(`{"a":1}`)
+	require.Equal(t, errStop, err)
+
+	data, err := parser.EnsureJSON(`{"emotion":" 疑惑 ","roles":x}`)
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+	require.Equal(t, `{"emotion":"confused"}`, data)
+
+	doc := &Document{Value: map[string]any{"a": []any{1.0}}}
+	require.Nil(t, doc.Set("a.0", 2.0))
+	require.Equal(t, ErrPathNotFound, doc.Set("a.1", 2.0))
+	require.Equal(t, ErrPathNotFound, doc.Set("b.c", 2.0))
+	require.Equal(t, map[string]any{"a": []any{2.0}}, doc.Value)
+}